@@ -22,6 +22,11 @@ data "gemctl_data_store" "existing_store" {
   data_store_id = "document-store"
 }
 
+# Look up the schema of the data store
+data "gemctl_data_store_schema" "existing_schema" {
+  data_store_id = data.gemctl_data_store.existing_store.data_store_id
+}
+
 output "engine_details" {
   value = {
     name          = data.gemctl_engine.existing_engine.name
@@ -38,3 +43,10 @@ output "data_store_details" {
     content_config = data.gemctl_data_store.existing_store.content_config
   }
 }
+
+output "searchable_fields" {
+  value = [
+    for field in data.gemctl_data_store_schema.existing_schema.field_configs : field.field_path
+    if field.searchable
+  ]
+}
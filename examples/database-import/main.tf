@@ -0,0 +1,103 @@
+terraform {
+  required_providers {
+    gemctl = {
+      source  = "vb140772/gemctl"
+      version = "~> 0.1"
+    }
+  }
+}
+
+provider "gemctl" {
+  project_id = "your-project-id"
+  location   = "us"
+}
+
+variable "build_id" {
+  description = "ID of the pipeline run that refreshed the orders table; a new value re-imports it"
+  type        = string
+  default     = "initial"
+}
+
+# Structured data store indexing the operational databases
+resource "gemctl_data_store" "orders" {
+  data_store_id  = "order-store"
+  display_name   = "Order Store"
+  content_config = "NO_CONTENT"
+}
+
+# Import the orders table from Cloud SQL, using the order_id column as document ID.
+# Every new build_id runs the import again.
+resource "gemctl_documents_import" "cloud_sql_orders" {
+  data_store_id = gemctl_data_store.orders.data_store_id
+  id_field      = "order_id"
+
+  triggers = {
+    build_id = var.build_id
+  }
+
+  cloud_sql_source {
+    instance_id     = "orders-instance"
+    database_id     = "shop"
+    table_id        = "orders"
+    gcs_staging_dir = "gs://your-bucket/cloud-sql-staging/"
+  }
+}
+
+# Import the customers table from Spanner with Data Boost
+resource "gemctl_documents_import" "spanner_customers" {
+  data_store_id = gemctl_data_store.orders.data_store_id
+  id_field      = "customer_id"
+
+  spanner_source {
+    instance_id       = "crm-instance"
+    database_id       = "crm"
+    table_id          = "customers"
+    enable_data_boost = true
+  }
+}
+
+# Import the reviews collection from Firestore
+resource "gemctl_documents_import" "firestore_reviews" {
+  data_store_id     = gemctl_data_store.orders.data_store_id
+  auto_generate_ids = true
+
+  firestore_source {
+    database_id   = "(default)"
+    collection_id = "reviews"
+  }
+}
+
+# Import product events from Bigtable, storing the row key in the id field
+resource "gemctl_documents_import" "bigtable_events" {
+  data_store_id       = gemctl_data_store.orders.data_store_id
+  id_field            = "id"
+  max_import_failures = 100
+
+  bigtable_source {
+    instance_id    = "events-instance"
+    table_id       = "product-events"
+    key_field_name = "id"
+
+    column_family {
+      name       = "event"
+      field_name = "event"
+      type       = "STRING"
+
+      column {
+        qualifier  = "count"
+        field_name = "count"
+        type       = "INTEGER"
+        encoding   = "BINARY"
+      }
+    }
+  }
+}
+
+output "imported_documents" {
+  value = {
+    cloud_sql = gemctl_documents_import.cloud_sql_orders.result.success_count
+    spanner   = gemctl_documents_import.spanner_customers.result.success_count
+    firestore = gemctl_documents_import.firestore_reviews.result.success_count
+    bigtable  = gemctl_documents_import.bigtable_events.result.success_count
+  }
+}
@@ -0,0 +1,41 @@
+terraform {
+  required_providers {
+    gemctl = {
+      source  = "vb140772/gemctl"
+      version = "~> 0.1"
+    }
+  }
+}
+
+provider "gemctl" {
+  project_id = "your-project-id"
+  location   = "us"
+}
+
+# Data store holding the policy documents kept in this repository
+resource "gemctl_data_store" "policies" {
+  data_store_id = "policy-store"
+  display_name  = "Policy Store"
+}
+
+# Sync every Markdown and PDF file below policies/ into the data store.
+# Files added, edited or deleted in the repository are created, updated or
+# deleted on the next apply; other documents in the branch are left alone.
+resource "gemctl_document_set" "policies" {
+  data_store_id = gemctl_data_store.policies.data_store_id
+  directory     = "${path.module}/policies"
+  patterns      = ["**/*.md", "**/*.pdf"]
+  parallelism   = 8
+}
+
+output "synced_documents" {
+  value = keys(gemctl_document_set.policies.documents)
+}
+
+output "last_sync" {
+  value = {
+    added   = gemctl_document_set.policies.documents_added
+    changed = gemctl_document_set.policies.documents_changed
+    removed = gemctl_document_set.policies.documents_removed
+  }
+}
@@ -22,7 +22,25 @@ resource "gemctl_data_store" "documents" {
 resource "gemctl_data_store" "presentations" {
   data_store_id = "presentation-store"
   display_name  = "Presentation Store"
-  gcs_uri       = "gs://your-bucket/presentations/*"
+
+  # Raw PDF and PPTX files from several prefixes become one document each
+  gcs_uris = [
+    "gs://your-bucket/presentations/*.pdf",
+    "gs://your-bucket/presentations/*.pptx",
+  ]
+  data_schema = "content"
+
+  # Parse slides with the layout parser and split them into small chunks
+  document_processing_config {
+    default_parsing_config {
+      parser = "layout"
+    }
+
+    chunking_config {
+      chunk_size                = 300
+      include_ancestor_headings = true
+    }
+  }
 }
 
 resource "gemctl_data_store" "videos" {
@@ -31,6 +49,19 @@ resource "gemctl_data_store" "videos" {
   gcs_uri       = "gs://your-bucket/videos/*"
 }
 
+# Index the product catalog straight from BigQuery
+resource "gemctl_data_store" "products" {
+  data_store_id  = "product-store"
+  display_name   = "Product Store"
+  content_config = "NO_CONTENT"
+
+  bigquery_source {
+    dataset_id  = "catalog"
+    table_id    = "products"
+    data_schema = "custom"
+  }
+}
+
 # Create an engine that connects to all data stores
 resource "gemctl_engine" "unified_search" {
   engine_id    = "unified-search-engine"
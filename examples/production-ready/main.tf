@@ -19,6 +19,16 @@ resource "gemctl_data_store" "production_docs" {
   data_store_id = "prod-document-store"
   display_name  = "Production Document Store"
   gcs_uri       = "gs://prod-documents-bucket/*"
+
+  # Fail the apply when more than a handful of documents are rejected, and
+  # keep the errors of every rejected document for triage
+  max_import_failures     = 10
+  import_error_gcs_prefix = "gs://prod-documents-bucket-errors/import/"
+
+  # Large imports can take well over the default create timeout
+  timeouts {
+    create = "3h"
+  }
 }
 
 resource "gemctl_data_store" "staging_docs" {
@@ -32,12 +42,23 @@ resource "gemctl_engine" "production" {
   engine_id    = "prod-search-engine"
   display_name = "Production Search Engine"
   data_stores  = [gemctl_data_store.production_docs.id]
+  company_name = "Example Corp"
+
+  search_engine_config {
+    search_tier    = "SEARCH_TIER_ENTERPRISE"
+    search_add_ons = ["SEARCH_ADD_ON_LLM"]
+  }
 }
 
 resource "gemctl_engine" "staging" {
   engine_id    = "staging-search-engine"
   display_name = "Staging Search Engine"
   data_stores  = [gemctl_data_store.staging_docs.id]
+
+  # The standard tier keeps non-production costs down
+  search_engine_config {
+    search_tier = "SEARCH_TIER_STANDARD"
+  }
 }
 
 # 3. Use outputs to get resource information
@@ -59,6 +80,10 @@ output "staging_engine" {
   }
 }
 
+output "production_import" {
+  value = gemctl_data_store.production_docs.last_import
+}
+
 # 4. Use variables for flexibility
 variable "environment" {
   description = "Environment name (dev, staging, prod)"
@@ -0,0 +1,5 @@
+# Data stores can be imported by data store ID
+terraform import gemctl_data_store.example my-data-store
+
+# or by full resource name in the provider's location and collection
+terraform import gemctl_data_store.example projects/my-project/locations/global/collections/default_collection/dataStores/my-data-store
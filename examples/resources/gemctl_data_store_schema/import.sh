@@ -0,0 +1,8 @@
+# Data store schemas can be imported by data store ID, which selects default_schema
+terraform import gemctl_data_store_schema.example my-data-store
+
+# by data store ID and schema ID
+terraform import gemctl_data_store_schema.example my-data-store/default_schema
+
+# or by full resource name in the provider's location and collection
+terraform import gemctl_data_store_schema.example projects/my-project/locations/global/collections/default_collection/dataStores/my-data-store/schemas/default_schema
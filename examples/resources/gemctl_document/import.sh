@@ -0,0 +1,8 @@
+# Documents can be imported by data store ID and document ID, which selects default_branch
+terraform import gemctl_document.example my-data-store/my-document
+
+# by data store ID, branch and document ID
+terraform import gemctl_document.example my-data-store/default_branch/my-document
+
+# or by full resource name in the provider's location and collection
+terraform import gemctl_document.example projects/my-project/locations/global/collections/default_collection/dataStores/my-data-store/branches/default_branch/documents/my-document
@@ -0,0 +1,5 @@
+# Engines can be imported by engine ID
+terraform import gemctl_engine.example my-search-engine
+
+# or by full resource name in the provider's location and collection
+terraform import gemctl_engine.example projects/my-project/locations/global/collections/default_collection/engines/my-search-engine
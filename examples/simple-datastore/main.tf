@@ -19,6 +19,34 @@ resource "gemctl_data_store" "documents" {
   gcs_uri       = "gs://your-bucket/documents/*"
 }
 
+# Create an empty data store that is fed by another pipeline
+resource "gemctl_data_store" "pipeline" {
+  data_store_id = "pipeline-store"
+  display_name  = "Pipeline Store"
+}
+
+# Write a document with structured data into the pipeline store
+resource "gemctl_document" "faq" {
+  data_store_id = gemctl_data_store.pipeline.data_store_id
+  document_id   = "faq"
+
+  struct_data = jsonencode({
+    title    = "Frequently asked questions"
+    category = "support"
+  })
+}
+
+# Upload a local file as a document; editing the file updates the document
+resource "gemctl_document" "welcome" {
+  data_store_id = gemctl_data_store.pipeline.data_store_id
+  document_id   = "welcome"
+
+  content {
+    mime_type = "text/plain"
+    file      = "${path.module}/docs/welcome.txt"
+  }
+}
+
 output "data_store_name" {
   value = gemctl_data_store.documents.name
 }
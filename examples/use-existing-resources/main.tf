@@ -45,3 +45,15 @@ resource "gemctl_engine" "new_engine" {
   display_name = "New Engine with Existing Store"
   data_stores  = [data.gemctl_data_store.existing.data_store_id]
 }
+
+# Example 6: Adopt an engine created in the console so Terraform manages it.
+# The import ID may be the engine ID or its full resource name.
+import {
+  to = gemctl_engine.adopted
+  id = "my-console-engine"
+}
+
+resource "gemctl_engine" "adopted" {
+  engine_id    = "my-console-engine"
+  display_name = "Engine Created in the Console"
+}
@@ -3,24 +3,31 @@ package client
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"os/exec"
 	"strings"
+	"sync"
 	"time"
 
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
 	"google.golang.org/api/discoveryengine/v1"
 	"google.golang.org/api/option"
+	htransport "google.golang.org/api/transport/http"
 )
 
 // Config holds the configuration for the Gemini client
 type Config struct {
-	ProjectID           string
-	Location            string
-	Collection          string
-	UseServiceAccount   bool
-	Format              string
+	ProjectID         string
+	Location          string
+	Collection        string
+	UseServiceAccount bool
+	Format            string
+	// MaxRetries is the number of times a request failing with a transient error is retried
+	MaxRetries int
+	// RetryMaxBackoff caps the delay between retries; zero uses DefaultRetryMaxBackoff
+	RetryMaxBackoff time.Duration
 }
 
 // GeminiClient handles interactions with the Gemini Enterprise API
@@ -31,16 +38,16 @@ type GeminiClient struct {
 
 // Engine represents a Gemini Enterprise engine
 type Engine struct {
-	Name                string                 `json:"name"`
-	DisplayName         string                 `json:"displayName"`
-	SolutionType        string                 `json:"solutionType"`
-	IndustryVertical    string                 `json:"industryVertical"`
-	AppType             string                 `json:"appType"`
-	CreateTime          string                 `json:"createTime"`
-	DataStoreIds        []string               `json:"dataStoreIds,omitempty"`
-	SearchEngineConfig  *SearchEngineConfig    `json:"searchEngineConfig,omitempty"`
-	CommonConfig        map[string]interface{} `json:"commonConfig,omitempty"`
-	Features            map[string]string      `json:"features,omitempty"`
+	Name               string                 `json:"name"`
+	DisplayName        string                 `json:"displayName"`
+	SolutionType       string                 `json:"solutionType"`
+	IndustryVertical   string                 `json:"industryVertical"`
+	AppType            string                 `json:"appType"`
+	CreateTime         string                 `json:"createTime"`
+	DataStoreIds       []string               `json:"dataStoreIds,omitempty"`
+	SearchEngineConfig *SearchEngineConfig    `json:"searchEngineConfig,omitempty"`
+	CommonConfig       map[string]interface{} `json:"commonConfig,omitempty"`
+	Features           map[string]string      `json:"features,omitempty"`
 }
 
 // SearchEngineConfig represents search engine configuration
@@ -51,16 +58,45 @@ type SearchEngineConfig struct {
 
 // DataStore represents a Gemini Enterprise data store
 type DataStore struct {
-	Name                     string                 `json:"name"`
-	DisplayName              string                 `json:"displayName"`
-	IndustryVertical         string                 `json:"industryVertical"`
-	ContentConfig           string                 `json:"contentConfig"`
-	CreateTime              string                 `json:"createTime"`
-	SolutionTypes           []string               `json:"solutionTypes,omitempty"`
-	AclEnabled              bool                   `json:"aclEnabled,omitempty"`
-	BillingEstimation       *BillingEstimation     `json:"billingEstimation,omitempty"`
-	DocumentProcessingConfig map[string]interface{} `json:"documentProcessingConfig,omitempty"`
-	Schema                  map[string]interface{} `json:"schema,omitempty"`
+	Name                     string                    `json:"name"`
+	DisplayName              string                    `json:"displayName"`
+	IndustryVertical         string                    `json:"industryVertical"`
+	ContentConfig            string                    `json:"contentConfig"`
+	CreateTime               string                    `json:"createTime"`
+	SolutionTypes            []string                  `json:"solutionTypes,omitempty"`
+	AclEnabled               bool                      `json:"aclEnabled,omitempty"`
+	BillingEstimation        *BillingEstimation        `json:"billingEstimation,omitempty"`
+	DocumentProcessingConfig *DocumentProcessingConfig `json:"documentProcessingConfig,omitempty"`
+	Schema                   map[string]interface{}    `json:"schema,omitempty"`
+}
+
+// DocumentProcessingConfig describes how the documents of a data store are parsed and chunked
+type DocumentProcessingConfig struct {
+	DefaultParsingConfig *ParsingConfig `json:"defaultParsingConfig,omitempty"`
+	// ParsingConfigOverrides maps a file type such as "pdf" to the parser used for it
+	ParsingConfigOverrides map[string]ParsingConfig `json:"parsingConfigOverrides,omitempty"`
+	ChunkingConfig         *ChunkingConfig          `json:"chunkingConfig,omitempty"`
+}
+
+// Parsers supported by ParsingConfig
+const (
+	ParserDigital = "digital"
+	ParserOCR     = "ocr"
+	ParserLayout  = "layout"
+)
+
+// ParsingConfig selects the parser for documents
+type ParsingConfig struct {
+	// Parser is one of ParserDigital, ParserOCR or ParserLayout
+	Parser string `json:"parser"`
+	// UseNativeText makes the OCR parser use native text on pages that contain it
+	UseNativeText bool `json:"useNativeText,omitempty"`
+}
+
+// ChunkingConfig represents layout based chunking configuration
+type ChunkingConfig struct {
+	ChunkSize               int64 `json:"chunkSize,omitempty"`
+	IncludeAncestorHeadings bool  `json:"includeAncestorHeadings,omitempty"`
 }
 
 // BillingEstimation represents billing information
@@ -71,41 +107,38 @@ type BillingEstimation struct {
 
 // Document represents a document in a data store
 type Document struct {
-	ID         string                 `json:"id"`
-	Content    map[string]interface{} `json:"content"`
-	IndexTime  string                 `json:"indexTime"`
+	ID               string `json:"id"`
+	Name             string `json:"name,omitempty"`
+	SchemaID         string `json:"schemaId,omitempty"`
+	ParentDocumentID string `json:"parentDocumentId,omitempty"`
+	// StructData and JSONData hold the structured data of the document as JSON
+	StructData string `json:"structData,omitempty"`
+	JSONData   string `json:"jsonData,omitempty"`
+	// Content holds the mimeType and uri of the unstructured content, if any
+	Content   map[string]interface{} `json:"content"`
+	IndexTime string                 `json:"indexTime"`
 }
 
 // CreateResult represents the result of a create operation
 type CreateResult struct {
-	EngineName string `json:"engine_name,omitempty"`
+	EngineName    string `json:"engine_name,omitempty"`
 	DataStoreName string `json:"data_store_name,omitempty"`
-	ImportOperation map[string]interface{} `json:"import_operation,omitempty"`
-	Status string `json:"status"`
-	Error  string `json:"error,omitempty"`
-}
-
-// DeleteResult represents the result of a delete operation
-type DeleteResult struct {
-	Status  string `json:"status"`
-	Message string `json:"message"`
 }
 
 // NewGeminiClient creates a new Gemini client
-func NewGeminiClient(config *Config) (*GeminiClient, error) {
+func NewGeminiClient(ctx context.Context, config *Config) (*GeminiClient, error) {
 	// Set defaults
 	if config.Location == "" {
 		config.Location = getDefaultLocation()
 	}
 	if config.ProjectID == "" {
-		projectID, err := getDefaultProject()
+		projectID, err := getDefaultProject(ctx)
 		if err != nil {
 			return nil, fmt.Errorf("project ID is required: %w", err)
 		}
 		config.ProjectID = projectID
 	}
 
-	ctx := context.Background()
 	var service *discoveryengine.Service
 	var err error
 
@@ -122,25 +155,38 @@ func NewGeminiClient(config *Config) (*GeminiClient, error) {
 		baseURL = fmt.Sprintf("https://%s-discoveryengine.googleapis.com/", regionPrefix)
 	}
 
+	// Retry transient failures below the authorization layer
+	transport := newRetryTransport(http.DefaultTransport, config.MaxRetries, config.RetryMaxBackoff)
+
 	if config.UseServiceAccount {
-		// Use Application Default Credentials
-		service, err = discoveryengine.NewService(ctx, 
-			option.WithScopes(discoveryengine.CloudPlatformScope),
+		// Use Application Default Credentials. The token source keeps the context
+		// it is built with for later refreshes, so it must outlive the caller's;
+		// each request is still cancelled through its own context.
+		credCtx := context.WithoutCancel(ctx)
+		authTransport, err := htransport.NewTransport(credCtx, transport,
+			option.WithScopes(discoveryengine.CloudPlatformScope))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load Application Default Credentials: %w", err)
+		}
+
+		service, err = discoveryengine.NewService(credCtx,
+			option.WithHTTPClient(&http.Client{Transport: authTransport}),
 			option.WithEndpoint(baseURL))
 		if err != nil {
 			return nil, fmt.Errorf("failed to create service with ADC: %w", err)
 		}
 	} else {
-		// Use user credentials via gcloud auth print-access-token
-		tokenSource, err := getUserTokenSource()
-		if err != nil {
-			return nil, fmt.Errorf("failed to get user token source: %w", err)
+		// Use user credentials via gcloud auth print-access-token, billed to the quota project
+		httpClient := &http.Client{
+			Transport: &gcloudTransport{
+				base:         transport,
+				source:       &gcloudTokenSource{},
+				quotaProject: config.ProjectID,
+			},
 		}
-		
-		// Create service with user token source and quota project
-		service, err = discoveryengine.NewService(ctx, 
-			option.WithTokenSource(tokenSource),
-			option.WithQuotaProject(config.ProjectID),
+
+		service, err = discoveryengine.NewService(ctx,
+			option.WithHTTPClient(httpClient),
 			option.WithEndpoint(baseURL))
 		if err != nil {
 			return nil, fmt.Errorf("failed to create service with user credentials: %w", err)
@@ -158,30 +204,68 @@ func (c *GeminiClient) Config() *Config {
 	return c.config
 }
 
-// getUserTokenSource creates a token source using gcloud auth print-access-token
-func getUserTokenSource() (oauth2.TokenSource, error) {
-	return oauth2.ReuseTokenSource(nil, &gcloudTokenSource{}), nil
+// gcloudTokenSource obtains access tokens using gcloud auth print-access-token
+// and caches them until they are about to expire
+type gcloudTokenSource struct {
+	mu    sync.Mutex
+	token *oauth2.Token
 }
 
-// gcloudTokenSource implements oauth2.TokenSource using gcloud auth print-access-token
-type gcloudTokenSource struct{}
+// TokenContext returns the cached token, running gcloud under ctx when a new one is needed
+func (g *gcloudTokenSource) TokenContext(ctx context.Context) (*oauth2.Token, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.token.Valid() {
+		return g.token, nil
+	}
 
-func (g *gcloudTokenSource) Token() (*oauth2.Token, error) {
-	cmd := exec.Command("gcloud", "auth", "print-access-token")
+	cmd := exec.CommandContext(ctx, "gcloud", "auth", "print-access-token")
 	output, err := cmd.Output()
 	if err != nil {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("failed to get access token from gcloud: %w", ctx.Err())
+		}
 		return nil, fmt.Errorf("failed to get access token from gcloud: %w", err)
 	}
-	
-	token := strings.TrimSpace(string(output))
-	return &oauth2.Token{
-		AccessToken: token,
+
+	g.token = &oauth2.Token{
+		AccessToken: strings.TrimSpace(string(output)),
 		Expiry:      time.Now().Add(50 * time.Minute), // Tokens typically last 1 hour
-	}, nil
+	}
+	return g.token, nil
+}
+
+// gcloudTransport authorizes requests with gcloud user credentials. Tokens are
+// fetched under each request's context, so cancelling a request also stops a
+// pending gcloud subprocess.
+type gcloudTransport struct {
+	base         http.RoundTripper
+	source       *gcloudTokenSource
+	quotaProject string
+}
+
+func (t *gcloudTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.source.TokenContext(req.Context())
+	if err != nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, err
+	}
+
+	// A RoundTripper must not modify the caller's request
+	req = req.Clone(req.Context())
+	token.SetAuthHeader(req)
+	if t.quotaProject != "" {
+		req.Header.Set("X-Goog-User-Project", t.quotaProject)
+	}
+
+	return t.base.RoundTrip(req)
 }
 
 // getDefaultProject gets the default project from environment or gcloud config
-func getDefaultProject() (string, error) {
+func getDefaultProject(ctx context.Context) (string, error) {
 	// Try environment variables first
 	if project := os.Getenv("GOOGLE_CLOUD_PROJECT"); project != "" {
 		return project, nil
@@ -189,9 +273,9 @@ func getDefaultProject() (string, error) {
 	if project := os.Getenv("GCLOUD_PROJECT"); project != "" {
 		return project, nil
 	}
-	
+
 	// Try gcloud config
-	cmd := exec.Command("gcloud", "config", "get-value", "project")
+	cmd := exec.CommandContext(ctx, "gcloud", "config", "get-value", "project")
 	output, err := cmd.Output()
 	if err == nil {
 		project := strings.TrimSpace(string(output))
@@ -199,14 +283,13 @@ func getDefaultProject() (string, error) {
 			return project, nil
 		}
 	}
-	
+
 	// Try from credentials
-	ctx := context.Background()
 	creds, err := google.FindDefaultCredentials(ctx, discoveryengine.CloudPlatformScope)
 	if err == nil && creds.ProjectID != "" {
 		return creds.ProjectID, nil
 	}
-	
+
 	return "", fmt.Errorf("no project ID found in environment variables, gcloud config, or credentials")
 }
 
@@ -1,219 +1,340 @@
 package client
 
 import (
+	"context"
 	"fmt"
-	"time"
+	"strings"
 
 	"google.golang.org/api/discoveryengine/v1"
+	"google.golang.org/api/iterator"
 )
 
-// ListDataStores lists all data stores in the project
-func (c *GeminiClient) ListDataStores() ([]*DataStore, error) {
+// ListDataStores lists all data stores in the project, following every result page
+func (c *GeminiClient) ListDataStores(ctx context.Context, opts *ListOptions) ([]*DataStore, error) {
 	parent := fmt.Sprintf("projects/%s/locations/%s", c.config.ProjectID, c.config.Location)
-	
+
 	call := c.service.Projects.Locations.DataStores.List(parent)
-	response, err := call.Do()
-	if err != nil {
-		return nil, fmt.Errorf("failed to list data stores: %w", err)
+	if opts != nil {
+		if opts.PageSize > 0 {
+			call.PageSize(opts.PageSize)
+		}
+		if opts.Filter != "" {
+			call.Filter(opts.Filter)
+		}
 	}
-	
+
 	var dataStores []*DataStore
-	for _, ds := range response.DataStores {
-		dataStores = append(dataStores, convertDataStore(ds))
+	err := call.Pages(ctx, func(response *discoveryengine.GoogleCloudDiscoveryengineV1ListDataStoresResponse) error {
+		for _, ds := range response.DataStores {
+			dataStores = append(dataStores, convertDataStore(ds))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, newAPIError("list data stores", err)
 	}
-	
+
 	return dataStores, nil
 }
 
 // GetDataStoreDetails gets detailed information about a specific data store
-func (c *GeminiClient) GetDataStoreDetails(dataStoreName string) (*DataStore, error) {
+func (c *GeminiClient) GetDataStoreDetails(ctx context.Context, dataStoreName string) (*DataStore, error) {
 	call := c.service.Projects.Locations.DataStores.Get(dataStoreName)
-	dataStore, err := call.Do()
+	dataStore, err := call.Context(ctx).Do()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get data store details: %w", err)
+		return nil, newAPIError("get data store details", err)
 	}
-	
+
 	return convertDataStore(dataStore), nil
 }
 
-// GetDataStoreSchema gets the schema for a data store
-func (c *GeminiClient) GetDataStoreSchema(dataStoreName string) (map[string]interface{}, error) {
-	schemaName := fmt.Sprintf("%s/schemas/default_schema", dataStoreName)
-	call := c.service.Projects.Locations.DataStores.Schemas.Get(schemaName)
-	schema, err := call.Do()
+// GetDataStoreSchema gets the default schema of a data store, including its
+// JSON schema and the field configs derived from it
+func (c *GeminiClient) GetDataStoreSchema(ctx context.Context, dataStoreName string) (map[string]interface{}, error) {
+	schema, err := c.GetSchema(ctx, fmt.Sprintf("%s/schemas/%s", dataStoreName, DefaultSchemaID))
 	if err != nil {
-		return nil, fmt.Errorf("failed to get data store schema: %w", err)
+		return nil, err
 	}
-	
+
 	// Convert schema to map for easier handling
-	schemaMap := make(map[string]interface{})
-	if schema.Name != "" {
-		schemaMap["name"] = schema.Name
-	}
-	// Note: FieldConfigs field may not exist in current API version
-	// if schema.FieldConfigs != nil {
-	//     schemaMap["fieldConfigs"] = schema.FieldConfigs
-	// }
-	
+	schemaMap := map[string]interface{}{
+		"name": schema.Name,
+	}
+	if schema.JSONSchema != "" {
+		schemaMap["jsonSchema"] = schema.JSONSchema
+	}
+	if fieldConfigs, err := schema.FieldConfigs(); err == nil && len(fieldConfigs) > 0 {
+		schemaMap["fieldConfigs"] = fieldConfigs
+	}
+
 	return schemaMap, nil
 }
 
-// CreateDataStoreFromGCS creates a data store and imports data from GCS bucket
-func (c *GeminiClient) CreateDataStoreFromGCS(dataStoreID, displayName, gcsURI, dataSchema, reconciliationMode string) (*CreateResult, error) {
+// DataStoreOptions holds the settings a data store is created with. Empty
+// fields fall back to the defaults of a generic search data store with content.
+type DataStoreOptions struct {
+	IndustryVertical string
+	SolutionTypes    []string
+	ContentConfig    string
+	AclEnabled       bool
+	// DocumentProcessingConfig is only sent when set; the API then uses the digital parser
+	DocumentProcessingConfig *DocumentProcessingConfig
+}
+
+// Default data store settings used for fields left empty in DataStoreOptions
+const (
+	DefaultDataStoreIndustryVertical = "GENERIC"
+	DefaultDataStoreSolutionType     = "SOLUTION_TYPE_SEARCH"
+	DefaultDataStoreContentConfig    = "CONTENT_REQUIRED"
+)
+
+// CreateDataStore creates an empty data store and waits for it to become available.
+// Documents are loaded separately with ImportDocuments.
+func (c *GeminiClient) CreateDataStore(ctx context.Context, dataStoreID, displayName string, options *DataStoreOptions) (*CreateResult, error) {
 	collectionName := fmt.Sprintf("projects/%s/locations/%s/collections/%s",
 		c.config.ProjectID, c.config.Location, c.config.Collection)
-	
-	// Step 1: Create the data store
+
+	if options == nil {
+		options = &DataStoreOptions{}
+	}
+
+	solutionTypes := options.SolutionTypes
+	if len(solutionTypes) == 0 {
+		solutionTypes = []string{DefaultDataStoreSolutionType}
+	}
+
 	dataStoreConfig := &discoveryengine.GoogleCloudDiscoveryengineV1DataStore{
 		DisplayName:      displayName,
-		IndustryVertical: "GENERIC",
-		SolutionTypes:    []string{"SOLUTION_TYPE_SEARCH"},
-		ContentConfig:    "CONTENT_REQUIRED",
+		IndustryVertical: valueOrDefault(options.IndustryVertical, DefaultDataStoreIndustryVertical),
+		SolutionTypes:    solutionTypes,
+		ContentConfig:    valueOrDefault(options.ContentConfig, DefaultDataStoreContentConfig),
+		AclEnabled:       options.AclEnabled,
 	}
-	
+
+	if options.DocumentProcessingConfig != nil {
+		dataStoreConfig.DocumentProcessingConfig = toAPIDocumentProcessingConfig(options.DocumentProcessingConfig)
+	}
+
 	call := c.service.Projects.Locations.Collections.DataStores.Create(collectionName, dataStoreConfig)
 	call.DataStoreId(dataStoreID)
-	
-	_, err := call.Do()
-	if err != nil {
-		return &CreateResult{
-			Status: "error",
-			Error:  fmt.Sprintf("Failed to create data store: %v", err),
-		}, nil
-	}
-	
-	// For now, construct the expected data store name
-	actualDataStoreName := fmt.Sprintf("projects/%s/locations/%s/collections/%s/dataStores/%s",
-		c.config.ProjectID, c.config.Location, c.config.Collection, dataStoreID)
-	
-	// Step 3: Import documents from GCS
-	branchName := fmt.Sprintf("%s/branches/default_branch", actualDataStoreName)
-	
-	importConfig := &discoveryengine.GoogleCloudDiscoveryengineV1ImportDocumentsRequest{
-		GcsSource: &discoveryengine.GoogleCloudDiscoveryengineV1GcsSource{
-			InputUris:  []string{gcsURI},
-			DataSchema: dataSchema,
-		},
-		ReconciliationMode: reconciliationMode,
-	}
-	
-	importCall := c.service.Projects.Locations.DataStores.Branches.Documents.Import(branchName, importConfig)
-	_, err = importCall.Do()
+
+	operation, err := call.Context(ctx).Do()
 	if err != nil {
-		return &CreateResult{
-			Status: "error",
-			Error:  fmt.Sprintf("Failed to import documents: %v", err),
-		}, nil
+		return nil, newAPIError("create data store", err)
+	}
+
+	var dataStore discoveryengine.GoogleCloudDiscoveryengineV1DataStore
+	if err := c.waitForOperation(ctx, operation, &dataStore, nil); err != nil {
+		return nil, newAPIError("create data store", err)
 	}
-	
+
+	actualDataStoreName := dataStore.Name
+	if actualDataStoreName == "" {
+		// Fallback: construct the expected data store name
+		actualDataStoreName = fmt.Sprintf("%s/dataStores/%s", collectionName, dataStoreID)
+	}
+
 	return &CreateResult{
 		DataStoreName: actualDataStoreName,
-		ImportOperation: map[string]interface{}{
-			"name": "import-operation",
-		},
-		Status: "success",
 	}, nil
 }
 
-// ListDocuments lists documents in a data store branch
-func (c *GeminiClient) ListDocuments(dataStoreName, branch string) ([]*Document, error) {
-	branchName := fmt.Sprintf("%s/branches/%s", dataStoreName, branch)
-	
-	call := c.service.Projects.Locations.DataStores.Branches.Documents.List(branchName)
-	response, err := call.Do()
+// DataStoreUpdate holds new values for the mutable fields of a data store. Nil
+// fields are left unchanged and excluded from the update mask.
+type DataStoreUpdate struct {
+	DisplayName *string
+}
+
+// PatchDataStore updates a data store in place, sending only the fields set in update
+func (c *GeminiClient) PatchDataStore(ctx context.Context, dataStoreName string, update *DataStoreUpdate) (*DataStore, error) {
+	dataStoreConfig := &discoveryengine.GoogleCloudDiscoveryengineV1DataStore{}
+	var updateMask []string
+
+	if update.DisplayName != nil {
+		dataStoreConfig.DisplayName = *update.DisplayName
+		updateMask = append(updateMask, "display_name")
+	}
+
+	if len(updateMask) == 0 {
+		return c.GetDataStoreDetails(ctx, dataStoreName)
+	}
+
+	call := c.service.Projects.Locations.Collections.DataStores.Patch(dataStoreName, dataStoreConfig)
+	call.UpdateMask(strings.Join(updateMask, ","))
+
+	dataStore, err := call.Context(ctx).Do()
 	if err != nil {
-		return nil, fmt.Errorf("failed to list documents: %w", err)
+		return nil, newAPIError("update data store", err)
 	}
-	
+
+	return convertDataStore(dataStore), nil
+}
+
+// ListDocuments lists all documents in a data store branch. For large branches
+// prefer Documents, which fetches one page at a time.
+func (c *GeminiClient) ListDocuments(ctx context.Context, dataStoreName, branch string, opts *ListOptions) ([]*Document, error) {
+	it := c.Documents(ctx, dataStoreName, branch, opts)
+
 	var documents []*Document
-	for _, doc := range response.Documents {
-		documents = append(documents, convertDocument(doc))
+	for {
+		doc, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		documents = append(documents, doc)
 	}
-	
+
 	return documents, nil
 }
 
-// DeleteDataStore deletes a data store
-func (c *GeminiClient) DeleteDataStore(dataStoreName string) (*DeleteResult, error) {
+// DeleteDataStore deletes a data store and waits for the deletion to complete
+func (c *GeminiClient) DeleteDataStore(ctx context.Context, dataStoreName string) error {
 	call := c.service.Projects.Locations.DataStores.Delete(dataStoreName)
-	_, err := call.Do()
+	operation, err := call.Context(ctx).Do()
 	if err != nil {
-		return &DeleteResult{
-			Status:  "error",
-			Message: fmt.Sprintf("Failed to delete data store: %v", err),
-		}, nil
-	}
-	
-	return &DeleteResult{
-		Status:  "success",
-		Message: "Data store deleted successfully",
-	}, nil
-}
+		return newAPIError("delete data store", err)
+	}
 
-// waitForDataStoreCreation waits for data store creation operation to complete
-func (c *GeminiClient) waitForDataStoreCreation(operationName, dataStoreID string) (string, error) {
-	maxWaitTime := 5 * time.Minute
-	checkInterval := 5 * time.Second
-	startTime := time.Now()
-	
-	for time.Since(startTime) < maxWaitTime {
-		operation, err := c.service.Projects.Locations.Operations.Get(operationName).Do()
-		if err != nil {
-			return "", fmt.Errorf("failed to check operation status: %w", err)
-		}
-		
-		if operation.Done {
-			if operation.Error != nil {
-				return "", fmt.Errorf("data store creation failed: %v", operation.Error)
-			}
-			
-		// Extract data store name from the response
-		if operation.Response != nil {
-			// Response is a byte slice, we need to handle it differently
-			// For now, construct the expected name
-			return fmt.Sprintf("projects/%s/locations/%s/collections/%s/dataStores/%s",
-				c.config.ProjectID, c.config.Location, c.config.Collection, dataStoreID), nil
-		}
-			
-			// Fallback: construct the expected data store name
-			return fmt.Sprintf("projects/%s/locations/%s/collections/%s/dataStores/%s",
-				c.config.ProjectID, c.config.Location, c.config.Collection, dataStoreID), nil
-		}
-		
-		time.Sleep(checkInterval)
+	if err := c.waitForOperation(ctx, operation, nil, nil); err != nil {
+		return newAPIError("delete data store", err)
 	}
-	
-	return "", fmt.Errorf("timeout waiting for data store creation")
+
+	return nil
 }
 
 // convertDataStore converts a Discovery Engine API data store to our DataStore struct
 func convertDataStore(ds *discoveryengine.GoogleCloudDiscoveryengineV1DataStore) *DataStore {
 	result := &DataStore{
-		Name:             ds.Name,
-		DisplayName:      ds.DisplayName,
-		IndustryVertical: ds.IndustryVertical,
-		ContentConfig:    ds.ContentConfig,
-		CreateTime:       ds.CreateTime,
-		SolutionTypes:    ds.SolutionTypes,
-		AclEnabled:       ds.AclEnabled,
-		DocumentProcessingConfig: make(map[string]interface{}),
-	}
-	
+		Name:                     ds.Name,
+		DisplayName:              ds.DisplayName,
+		IndustryVertical:         ds.IndustryVertical,
+		ContentConfig:            ds.ContentConfig,
+		CreateTime:               ds.CreateTime,
+		SolutionTypes:            ds.SolutionTypes,
+		AclEnabled:               ds.AclEnabled,
+		DocumentProcessingConfig: convertDocumentProcessingConfig(ds.DocumentProcessingConfig),
+	}
+
 	if ds.BillingEstimation != nil {
 		result.BillingEstimation = &BillingEstimation{
 			UnstructuredDataSize:       ds.BillingEstimation.UnstructuredDataSize,
 			UnstructuredDataUpdateTime: ds.BillingEstimation.UnstructuredDataUpdateTime,
 		}
 	}
-	
+
 	return result
 }
 
 // convertDocument converts a Discovery Engine API document to our Document struct
 func convertDocument(doc *discoveryengine.GoogleCloudDiscoveryengineV1Document) *Document {
-	return &Document{
-		ID:        doc.Id,
-		Content:   make(map[string]interface{}),
-		IndexTime: doc.IndexTime,
+	result := &Document{
+		ID:               doc.Id,
+		Name:             doc.Name,
+		SchemaID:         doc.SchemaId,
+		ParentDocumentID: doc.ParentDocumentId,
+		StructData:       string(doc.StructData),
+		JSONData:         doc.JsonData,
+		Content:          make(map[string]interface{}),
+		IndexTime:        doc.IndexTime,
+	}
+
+	if doc.Content != nil {
+		if doc.Content.MimeType != "" {
+			result.Content["mimeType"] = doc.Content.MimeType
+		}
+		if doc.Content.Uri != "" {
+			result.Content["uri"] = doc.Content.Uri
+		}
+	}
+
+	return result
+}
+
+// toAPIDocumentProcessingConfig converts our DocumentProcessingConfig to its Discovery Engine API form
+func toAPIDocumentProcessingConfig(config *DocumentProcessingConfig) *discoveryengine.GoogleCloudDiscoveryengineV1DocumentProcessingConfig {
+	result := &discoveryengine.GoogleCloudDiscoveryengineV1DocumentProcessingConfig{}
+
+	if config.DefaultParsingConfig != nil {
+		result.DefaultParsingConfig = toAPIParsingConfig(config.DefaultParsingConfig)
+	}
+
+	if len(config.ParsingConfigOverrides) > 0 {
+		result.ParsingConfigOverrides = make(map[string]discoveryengine.GoogleCloudDiscoveryengineV1DocumentProcessingConfigParsingConfig)
+		for fileType, parsingConfig := range config.ParsingConfigOverrides {
+			result.ParsingConfigOverrides[fileType] = *toAPIParsingConfig(&parsingConfig)
+		}
+	}
+
+	if config.ChunkingConfig != nil {
+		result.ChunkingConfig = &discoveryengine.GoogleCloudDiscoveryengineV1DocumentProcessingConfigChunkingConfig{
+			LayoutBasedChunkingConfig: &discoveryengine.GoogleCloudDiscoveryengineV1DocumentProcessingConfigChunkingConfigLayoutBasedChunkingConfig{
+				ChunkSize:               config.ChunkingConfig.ChunkSize,
+				IncludeAncestorHeadings: config.ChunkingConfig.IncludeAncestorHeadings,
+			},
+		}
+	}
+
+	return result
+}
+
+// toAPIParsingConfig converts a ParsingConfig to the API message selecting its parser
+func toAPIParsingConfig(config *ParsingConfig) *discoveryengine.GoogleCloudDiscoveryengineV1DocumentProcessingConfigParsingConfig {
+	result := &discoveryengine.GoogleCloudDiscoveryengineV1DocumentProcessingConfigParsingConfig{}
+
+	switch config.Parser {
+	case ParserOCR:
+		result.OcrParsingConfig = &discoveryengine.GoogleCloudDiscoveryengineV1DocumentProcessingConfigParsingConfigOcrParsingConfig{
+			UseNativeText: config.UseNativeText,
+		}
+	case ParserLayout:
+		result.LayoutParsingConfig = &discoveryengine.GoogleCloudDiscoveryengineV1DocumentProcessingConfigParsingConfigLayoutParsingConfig{}
+	default:
+		result.DigitalParsingConfig = &discoveryengine.GoogleCloudDiscoveryengineV1DocumentProcessingConfigParsingConfigDigitalParsingConfig{}
+	}
+
+	return result
+}
+
+// convertDocumentProcessingConfig converts a Discovery Engine API document processing config to our struct
+func convertDocumentProcessingConfig(config *discoveryengine.GoogleCloudDiscoveryengineV1DocumentProcessingConfig) *DocumentProcessingConfig {
+	if config == nil {
+		return nil
+	}
+
+	result := &DocumentProcessingConfig{}
+
+	if config.DefaultParsingConfig != nil {
+		result.DefaultParsingConfig = convertParsingConfig(config.DefaultParsingConfig)
+	}
+
+	if len(config.ParsingConfigOverrides) > 0 {
+		result.ParsingConfigOverrides = make(map[string]ParsingConfig)
+		for fileType, parsingConfig := range config.ParsingConfigOverrides {
+			result.ParsingConfigOverrides[fileType] = *convertParsingConfig(&parsingConfig)
+		}
+	}
+
+	if config.ChunkingConfig != nil && config.ChunkingConfig.LayoutBasedChunkingConfig != nil {
+		result.ChunkingConfig = &ChunkingConfig{
+			ChunkSize:               config.ChunkingConfig.LayoutBasedChunkingConfig.ChunkSize,
+			IncludeAncestorHeadings: config.ChunkingConfig.LayoutBasedChunkingConfig.IncludeAncestorHeadings,
+		}
+	}
+
+	return result
+}
+
+// convertParsingConfig converts an API parsing config to the parser it selects
+func convertParsingConfig(config *discoveryengine.GoogleCloudDiscoveryengineV1DocumentProcessingConfigParsingConfig) *ParsingConfig {
+	switch {
+	case config.OcrParsingConfig != nil:
+		return &ParsingConfig{Parser: ParserOCR, UseNativeText: config.OcrParsingConfig.UseNativeText}
+	case config.LayoutParsingConfig != nil:
+		return &ParsingConfig{Parser: ParserLayout}
+	default:
+		return &ParsingConfig{Parser: ParserDigital}
 	}
 }
@@ -0,0 +1,102 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+
+	"google.golang.org/api/discoveryengine/v1"
+	"google.golang.org/api/googleapi"
+)
+
+// DefaultBranch is the branch documents are read from and written to by default
+const DefaultBranch = "default_branch"
+
+// DocumentInput describes the data written to a document on create or update
+type DocumentInput struct {
+	SchemaID         string
+	ParentDocumentID string
+	// StructData and JSONData are JSON objects; at most one of them may be set
+	StructData string
+	JSONData   string
+	Content    *DocumentContent
+}
+
+// DocumentContent is the unstructured content of a document, given either as
+// raw bytes or as a GCS URI
+type DocumentContent struct {
+	MimeType string
+	RawBytes []byte
+	URI      string
+}
+
+// GetDocument gets a document
+func (c *GeminiClient) GetDocument(ctx context.Context, documentName string) (*Document, error) {
+	call := c.service.Projects.Locations.DataStores.Branches.Documents.Get(documentName)
+	doc, err := call.Context(ctx).Do()
+	if err != nil {
+		return nil, newAPIError("get document", err)
+	}
+
+	return convertDocument(doc), nil
+}
+
+// CreateDocument creates a document with the given ID on a data store branch
+func (c *GeminiClient) CreateDocument(ctx context.Context, branchName, documentID string, input *DocumentInput) (*Document, error) {
+	call := c.service.Projects.Locations.DataStores.Branches.Documents.Create(branchName, toAPIDocument(input))
+	call.DocumentId(documentID)
+
+	doc, err := call.Context(ctx).Do()
+	if err != nil {
+		return nil, newAPIError("create document", err)
+	}
+
+	return convertDocument(doc), nil
+}
+
+// UpdateDocument replaces the data of an existing document
+func (c *GeminiClient) UpdateDocument(ctx context.Context, documentName string, input *DocumentInput) (*Document, error) {
+	document := toAPIDocument(input)
+	document.Name = documentName
+
+	call := c.service.Projects.Locations.DataStores.Branches.Documents.Patch(documentName, document)
+	doc, err := call.Context(ctx).Do()
+	if err != nil {
+		return nil, newAPIError("update document", err)
+	}
+
+	return convertDocument(doc), nil
+}
+
+// DeleteDocument deletes a document
+func (c *GeminiClient) DeleteDocument(ctx context.Context, documentName string) error {
+	call := c.service.Projects.Locations.DataStores.Branches.Documents.Delete(documentName)
+	if _, err := call.Context(ctx).Do(); err != nil {
+		return newAPIError("delete document", err)
+	}
+
+	return nil
+}
+
+// toAPIDocument converts a DocumentInput to its Discovery Engine API form
+func toAPIDocument(input *DocumentInput) *discoveryengine.GoogleCloudDiscoveryengineV1Document {
+	doc := &discoveryengine.GoogleCloudDiscoveryengineV1Document{
+		SchemaId:         input.SchemaID,
+		ParentDocumentId: input.ParentDocumentID,
+		JsonData:         input.JSONData,
+	}
+	if input.StructData != "" {
+		doc.StructData = googleapi.RawMessage(input.StructData)
+	}
+
+	if input.Content != nil {
+		doc.Content = &discoveryengine.GoogleCloudDiscoveryengineV1DocumentContent{
+			MimeType: input.Content.MimeType,
+			Uri:      input.Content.URI,
+		}
+		if len(input.Content.RawBytes) > 0 {
+			doc.Content.RawBytes = base64.StdEncoding.EncodeToString(input.Content.RawBytes)
+		}
+	}
+
+	return doc
+}
@@ -1,168 +1,232 @@
 package client
 
 import (
+	"context"
 	"fmt"
-	"time"
+	"strings"
 
 	"google.golang.org/api/discoveryengine/v1"
 )
 
-// ListEngines lists all engines in a collection
-func (c *GeminiClient) ListEngines(collectionID string) ([]*Engine, error) {
-	parent := fmt.Sprintf("projects/%s/locations/%s/collections/%s", 
+// ListEngines lists all engines in a collection, following every result page
+func (c *GeminiClient) ListEngines(ctx context.Context, collectionID string, opts *ListOptions) ([]*Engine, error) {
+	parent := fmt.Sprintf("projects/%s/locations/%s/collections/%s",
 		c.config.ProjectID, c.config.Location, collectionID)
-	
+
 	call := c.service.Projects.Locations.Collections.Engines.List(parent)
-	response, err := call.Do()
-	if err != nil {
-		return nil, fmt.Errorf("failed to list engines: %w", err)
+	if opts != nil {
+		if opts.PageSize > 0 {
+			call.PageSize(opts.PageSize)
+		}
+		if opts.Filter != "" {
+			call.Filter(opts.Filter)
+		}
 	}
-	
+
 	var engines []*Engine
-	for _, engine := range response.Engines {
-		engines = append(engines, convertEngine(engine))
+	err := call.Pages(ctx, func(response *discoveryengine.GoogleCloudDiscoveryengineV1ListEnginesResponse) error {
+		for _, engine := range response.Engines {
+			engines = append(engines, convertEngine(engine))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, newAPIError("list engines", err)
 	}
-	
+
 	return engines, nil
 }
 
 // GetEngineDetails gets detailed information about a specific engine
-func (c *GeminiClient) GetEngineDetails(engineName string) (*Engine, error) {
+func (c *GeminiClient) GetEngineDetails(ctx context.Context, engineName string) (*Engine, error) {
 	call := c.service.Projects.Locations.Collections.Engines.Get(engineName)
-	engine, err := call.Do()
+	engine, err := call.Context(ctx).Do()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get engine details: %w", err)
+		return nil, newAPIError("get engine details", err)
 	}
-	
+
 	return convertEngine(engine), nil
 }
 
 // GetEngineFullConfig gets complete configuration for an engine including all data stores
-func (c *GeminiClient) GetEngineFullConfig(engineName string) (map[string]interface{}, error) {
-	engine, err := c.GetEngineDetails(engineName)
+func (c *GeminiClient) GetEngineFullConfig(ctx context.Context, engineName string) (map[string]interface{}, error) {
+	engine, err := c.GetEngineDetails(ctx, engineName)
 	if err != nil {
 		return nil, err
 	}
-	
+
 	config := map[string]interface{}{
 		"engine":      engine,
 		"data_stores": []interface{}{},
 	}
-	
+
 	// Get details for each data store
 	for _, dsID := range engine.DataStoreIds {
 		dsName := fmt.Sprintf("projects/%s/locations/%s/collections/%s/dataStores/%s",
 			c.config.ProjectID, c.config.Location, c.config.Collection, dsID)
-		
-		ds, err := c.GetDataStoreDetails(dsName)
+
+		ds, err := c.GetDataStoreDetails(ctx, dsName)
 		if err != nil {
 			continue // Skip failed data stores
 		}
-		
+
 		// Try to get schema as well
-		schema, err := c.GetDataStoreSchema(dsName)
+		schema, err := c.GetDataStoreSchema(ctx, dsName)
 		if err == nil && schema != nil {
 			ds.Schema = schema
 		}
-		
+
 		config["data_stores"] = append(config["data_stores"].([]interface{}), ds)
 	}
-	
+
 	return config, nil
 }
 
-// CreateSearchEngine creates a search engine connected to data stores
-func (c *GeminiClient) CreateSearchEngine(engineID, displayName string, dataStoreIDs []string, searchTier string) (*CreateResult, error) {
+// EngineOptions holds the settings an engine is created with. Empty fields
+// fall back to the defaults of a generic intranet search engine.
+type EngineOptions struct {
+	SolutionType     string
+	IndustryVertical string
+	AppType          string
+	CompanyName      string
+	// SearchEngineConfig is only sent when set; the API then defaults to the standard tier
+	SearchEngineConfig *SearchEngineConfig
+}
+
+// Default engine settings used for fields left empty in EngineOptions
+const (
+	DefaultEngineSolutionType     = "SOLUTION_TYPE_SEARCH"
+	DefaultEngineIndustryVertical = "GENERIC"
+	DefaultEngineAppType          = "APP_TYPE_INTRANET"
+)
+
+// DefaultSearchTier is the search tier the API applies when none is set
+const DefaultSearchTier = "SEARCH_TIER_STANDARD"
+
+// CreateSearchEngine creates an engine connected to data stores and waits for it to become available
+func (c *GeminiClient) CreateSearchEngine(ctx context.Context, engineID, displayName string, dataStoreIDs []string, options *EngineOptions) (*CreateResult, error) {
 	collectionName := fmt.Sprintf("projects/%s/locations/%s/collections/%s",
 		c.config.ProjectID, c.config.Location, c.config.Collection)
-	
+
+	if options == nil {
+		options = &EngineOptions{}
+	}
+
 	engineConfig := &discoveryengine.GoogleCloudDiscoveryengineV1Engine{
 		DisplayName:      displayName,
-		SolutionType:     "SOLUTION_TYPE_SEARCH",
-		IndustryVertical: "GENERIC",
-		AppType:          "APP_TYPE_INTRANET",
-		CommonConfig: &discoveryengine.GoogleCloudDiscoveryengineV1EngineCommonConfig{
-			CompanyName: "BCBSMA",
-		},
-	}
-	
+		SolutionType:     valueOrDefault(options.SolutionType, DefaultEngineSolutionType),
+		IndustryVertical: valueOrDefault(options.IndustryVertical, DefaultEngineIndustryVertical),
+		AppType:          valueOrDefault(options.AppType, DefaultEngineAppType),
+	}
+
+	if options.CompanyName != "" {
+		engineConfig.CommonConfig = &discoveryengine.GoogleCloudDiscoveryengineV1EngineCommonConfig{
+			CompanyName: options.CompanyName,
+		}
+	}
+
+	if options.SearchEngineConfig != nil {
+		engineConfig.SearchEngineConfig = toAPISearchEngineConfig(options.SearchEngineConfig)
+	}
+
 	// Only add dataStoreIds if data stores are provided
 	if len(dataStoreIDs) > 0 {
 		engineConfig.DataStoreIds = dataStoreIDs
 	}
-	
+
 	call := c.service.Projects.Locations.Collections.Engines.Create(collectionName, engineConfig)
 	call.EngineId(engineID)
-	
-	_, err := call.Do()
+
+	operation, err := call.Context(ctx).Do()
 	if err != nil {
-		return &CreateResult{
-			Status: "error",
-			Error:  fmt.Sprintf("Failed to create engine: %v", err),
-		}, nil
-	}
-	
-	// For now, construct the expected engine name
-	actualEngineName := fmt.Sprintf("projects/%s/locations/%s/collections/%s/engines/%s",
-		c.config.ProjectID, c.config.Location, c.config.Collection, engineID)
-	
+		return nil, newAPIError("create engine", err)
+	}
+
+	var engine discoveryengine.GoogleCloudDiscoveryengineV1Engine
+	if err := c.waitForOperation(ctx, operation, &engine, nil); err != nil {
+		return nil, newAPIError("create engine", err)
+	}
+
+	actualEngineName := engine.Name
+	if actualEngineName == "" {
+		// Fallback: construct the expected engine name
+		actualEngineName = fmt.Sprintf("%s/engines/%s", collectionName, engineID)
+	}
+
 	return &CreateResult{
 		EngineName: actualEngineName,
-		Status:     "success",
 	}, nil
 }
 
-// DeleteEngine deletes a search engine
-func (c *GeminiClient) DeleteEngine(engineName string) (*DeleteResult, error) {
-	call := c.service.Projects.Locations.Collections.Engines.Delete(engineName)
-	_, err := call.Do()
-	if err != nil {
-		return &DeleteResult{
-			Status:  "error",
-			Message: fmt.Sprintf("Failed to delete engine: %v", err),
-		}, nil
-	}
-	
-	return &DeleteResult{
-		Status:  "success",
-		Message: "Engine deleted successfully",
-	}, nil
+// EngineUpdate holds new values for the mutable fields of an engine. Nil fields
+// are left unchanged and excluded from the update mask.
+type EngineUpdate struct {
+	DisplayName        *string
+	DataStoreIDs       *[]string
+	CompanyName        *string
+	SearchEngineConfig *SearchEngineConfig
 }
 
-// waitForEngineCreation waits for engine creation operation to complete
-func (c *GeminiClient) waitForEngineCreation(operationName, engineID string) (string, error) {
-	maxWaitTime := 5 * time.Minute
-	checkInterval := 5 * time.Second
-	startTime := time.Now()
-	
-	for time.Since(startTime) < maxWaitTime {
-		operation, err := c.service.Projects.Locations.Operations.Get(operationName).Do()
-		if err != nil {
-			return "", fmt.Errorf("failed to check operation status: %w", err)
-		}
-		
-		if operation.Done {
-			if operation.Error != nil {
-				return "", fmt.Errorf("engine creation failed: %v", operation.Error)
-			}
-			
-		// Extract engine name from the response
-		if operation.Response != nil {
-			// Response is a byte slice, we need to handle it differently
-			// For now, construct the expected name
-			return fmt.Sprintf("projects/%s/locations/%s/collections/%s/engines/%s",
-				c.config.ProjectID, c.config.Location, c.config.Collection, engineID), nil
+// PatchEngine updates an engine in place, sending only the fields set in update
+func (c *GeminiClient) PatchEngine(ctx context.Context, engineName string, update *EngineUpdate) (*Engine, error) {
+	engineConfig := &discoveryengine.GoogleCloudDiscoveryengineV1Engine{}
+	var updateMask []string
+
+	if update.DisplayName != nil {
+		engineConfig.DisplayName = *update.DisplayName
+		updateMask = append(updateMask, "display_name")
+	}
+	if update.DataStoreIDs != nil {
+		engineConfig.DataStoreIds = *update.DataStoreIDs
+		engineConfig.ForceSendFields = append(engineConfig.ForceSendFields, "DataStoreIds")
+		updateMask = append(updateMask, "data_store_ids")
+	}
+	if update.CompanyName != nil {
+		engineConfig.CommonConfig = &discoveryengine.GoogleCloudDiscoveryengineV1EngineCommonConfig{
+			CompanyName:     *update.CompanyName,
+			ForceSendFields: []string{"CompanyName"},
 		}
-			
-			// Fallback: construct the expected engine name
-			return fmt.Sprintf("projects/%s/locations/%s/collections/%s/engines/%s",
-				c.config.ProjectID, c.config.Location, c.config.Collection, engineID), nil
+		updateMask = append(updateMask, "common_config.company_name")
+	}
+	if update.SearchEngineConfig != nil {
+		engineConfig.SearchEngineConfig = toAPISearchEngineConfig(update.SearchEngineConfig)
+		engineConfig.SearchEngineConfig.ForceSendFields = []string{"SearchAddOns"}
+		updateMask = append(updateMask, "search_engine_config.search_tier", "search_engine_config.search_add_ons")
+	}
+
+	if len(updateMask) == 0 {
+		return c.GetEngineDetails(ctx, engineName)
+	}
+
+	call := c.service.Projects.Locations.Collections.Engines.Patch(engineName, engineConfig)
+	call.UpdateMask(strings.Join(updateMask, ","))
+
+	engine, err := call.Context(ctx).Do()
+	if err != nil {
+		// Engines that do not support search tiers reject the search fields
+		// of the update mask; name them so the cause is clear
+		if update.SearchEngineConfig != nil && classifyError(err) == ErrInvalidArgument {
+			return nil, newAPIError("update engine search_engine_config", err)
 		}
-		
-		time.Sleep(checkInterval)
+		return nil, newAPIError("update engine", err)
 	}
-	
-	return "", fmt.Errorf("timeout waiting for engine creation")
+
+	return convertEngine(engine), nil
+}
+
+// DeleteEngine deletes a search engine and waits for the deletion to complete
+func (c *GeminiClient) DeleteEngine(ctx context.Context, engineName string) error {
+	call := c.service.Projects.Locations.Collections.Engines.Delete(engineName)
+	operation, err := call.Context(ctx).Do()
+	if err != nil {
+		return newAPIError("delete engine", err)
+	}
+
+	if err := c.waitForOperation(ctx, operation, nil, nil); err != nil {
+		return newAPIError("delete engine", err)
+	}
+
+	return nil
 }
 
 // convertEngine converts a Discovery Engine API engine to our Engine struct
@@ -178,11 +242,35 @@ func convertEngine(engine *discoveryengine.GoogleCloudDiscoveryengineV1Engine) *
 		CommonConfig:     make(map[string]interface{}),
 		Features:         engine.Features,
 	}
-	
+
 	// Convert CommonConfig if it exists
 	if engine.CommonConfig != nil {
 		result.CommonConfig["companyName"] = engine.CommonConfig.CompanyName
 	}
-	
+
+	// Convert SearchEngineConfig if it exists
+	if engine.SearchEngineConfig != nil {
+		result.SearchEngineConfig = &SearchEngineConfig{
+			SearchTier:   engine.SearchEngineConfig.SearchTier,
+			SearchAddOns: engine.SearchEngineConfig.SearchAddOns,
+		}
+	}
+
 	return result
 }
+
+// toAPISearchEngineConfig converts our SearchEngineConfig to its Discovery Engine API form
+func toAPISearchEngineConfig(config *SearchEngineConfig) *discoveryengine.GoogleCloudDiscoveryengineV1EngineSearchEngineConfig {
+	return &discoveryengine.GoogleCloudDiscoveryengineV1EngineSearchEngineConfig{
+		SearchTier:   config.SearchTier,
+		SearchAddOns: config.SearchAddOns,
+	}
+}
+
+// valueOrDefault returns value, or def when value is empty
+func valueOrDefault(value, def string) string {
+	if value == "" {
+		return def
+	}
+	return value
+}
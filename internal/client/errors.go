@@ -0,0 +1,139 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"google.golang.org/api/googleapi"
+)
+
+// Sentinel errors classifying failures reported by the Discovery Engine API.
+// Use errors.Is to test for them; the original googleapi.Error stays available
+// through errors.As.
+var (
+	ErrNotFound           = errors.New("resource not found")
+	ErrAlreadyExists      = errors.New("resource already exists")
+	ErrPermissionDenied   = errors.New("permission denied")
+	ErrFailedPrecondition = errors.New("failed precondition")
+	ErrResourceExhausted  = errors.New("resource exhausted")
+	ErrInvalidArgument    = errors.New("invalid argument")
+)
+
+// APIError is returned by client methods when a Discovery Engine API call fails
+type APIError struct {
+	// Action describes what the client was doing, e.g. "create engine"
+	Action string
+	// Kind is one of the sentinel errors above, or nil if the failure is unclassified
+	Kind error
+	// Err is the underlying error, usually a *googleapi.Error or *OperationError
+	Err error
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("failed to %s: %v", e.Action, e.Err)
+}
+
+func (e *APIError) Unwrap() []error {
+	if e.Kind == nil {
+		return []error{e.Err}
+	}
+	return []error{e.Kind, e.Err}
+}
+
+// newAPIError wraps err with the action that failed and its classification
+func newAPIError(action string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var timeoutErr *OperationTimeoutError
+	if errors.As(err, &timeoutErr) {
+		return &APIError{Action: action, Err: err}
+	}
+
+	return &APIError{
+		Action: action,
+		Kind:   classifyError(err),
+		Err:    err,
+	}
+}
+
+// classifyError maps an API or operation error to one of the sentinel errors
+func classifyError(err error) error {
+	var opErr *OperationError
+	if errors.As(err, &opErr) {
+		return errorKindFromRPCCode(opErr.Code)
+	}
+
+	var gErr *googleapi.Error
+	if !errors.As(err, &gErr) {
+		return nil
+	}
+
+	// Prefer the canonical status carried in the JSON body over the HTTP code
+	var body struct {
+		Error struct {
+			Status string `json:"status"`
+		} `json:"error"`
+	}
+	if json.Unmarshal([]byte(gErr.Body), &body) == nil && body.Error.Status != "" {
+		if kind := errorKindFromStatus(body.Error.Status); kind != nil {
+			return kind
+		}
+	}
+
+	switch gErr.Code {
+	case http.StatusBadRequest:
+		return ErrInvalidArgument
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusConflict:
+		return ErrAlreadyExists
+	case http.StatusForbidden:
+		return ErrPermissionDenied
+	case http.StatusTooManyRequests:
+		return ErrResourceExhausted
+	}
+
+	return nil
+}
+
+// errorKindFromStatus maps a canonical google.rpc.Code name to a sentinel error
+func errorKindFromStatus(status string) error {
+	switch status {
+	case "NOT_FOUND":
+		return ErrNotFound
+	case "ALREADY_EXISTS":
+		return ErrAlreadyExists
+	case "PERMISSION_DENIED":
+		return ErrPermissionDenied
+	case "FAILED_PRECONDITION":
+		return ErrFailedPrecondition
+	case "RESOURCE_EXHAUSTED":
+		return ErrResourceExhausted
+	case "INVALID_ARGUMENT":
+		return ErrInvalidArgument
+	}
+	return nil
+}
+
+// errorKindFromRPCCode maps a numeric google.rpc.Code to a sentinel error
+func errorKindFromRPCCode(code int64) error {
+	switch code {
+	case 3:
+		return ErrInvalidArgument
+	case 5:
+		return ErrNotFound
+	case 6:
+		return ErrAlreadyExists
+	case 7:
+		return ErrPermissionDenied
+	case 8:
+		return ErrResourceExhausted
+	case 9:
+		return ErrFailedPrecondition
+	}
+	return nil
+}
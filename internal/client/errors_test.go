@@ -0,0 +1,115 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"google.golang.org/api/googleapi"
+)
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{
+			name: "body status overrides HTTP code",
+			err: &googleapi.Error{
+				Code: http.StatusBadRequest,
+				Body: `{"error":{"code":400,"status":"FAILED_PRECONDITION","message":"data store is busy"}}`,
+			},
+			want: ErrFailedPrecondition,
+		},
+		{
+			name: "body status with a 403",
+			err: &googleapi.Error{
+				Code: http.StatusForbidden,
+				Body: `{"error":{"code":403,"status":"RESOURCE_EXHAUSTED"}}`,
+			},
+			want: ErrResourceExhausted,
+		},
+		{
+			name: "invalid argument status",
+			err: &googleapi.Error{
+				Code: http.StatusBadRequest,
+				Body: `{"error":{"code":400,"status":"INVALID_ARGUMENT"}}`,
+			},
+			want: ErrInvalidArgument,
+		},
+		{
+			name: "unknown body status falls back to HTTP code",
+			err: &googleapi.Error{
+				Code: http.StatusNotFound,
+				Body: `{"error":{"code":404,"status":"SOMETHING_NEW"}}`,
+			},
+			want: ErrNotFound,
+		},
+		{
+			name: "non-JSON body falls back to HTTP code",
+			err:  &googleapi.Error{Code: http.StatusConflict, Body: "conflict"},
+			want: ErrAlreadyExists,
+		},
+		{name: "HTTP 400", err: &googleapi.Error{Code: http.StatusBadRequest}, want: ErrInvalidArgument},
+		{name: "HTTP 403", err: &googleapi.Error{Code: http.StatusForbidden}, want: ErrPermissionDenied},
+		{name: "HTTP 404", err: &googleapi.Error{Code: http.StatusNotFound}, want: ErrNotFound},
+		{name: "HTTP 409", err: &googleapi.Error{Code: http.StatusConflict}, want: ErrAlreadyExists},
+		{name: "HTTP 429", err: &googleapi.Error{Code: http.StatusTooManyRequests}, want: ErrResourceExhausted},
+		{name: "HTTP 500", err: &googleapi.Error{Code: http.StatusInternalServerError}, want: nil},
+		{
+			name: "wrapped googleapi error",
+			err:  fmt.Errorf("get engine: %w", &googleapi.Error{Code: http.StatusNotFound}),
+			want: ErrNotFound,
+		},
+		{name: "operation invalid argument", err: &OperationError{Code: 3}, want: ErrInvalidArgument},
+		{name: "operation not found", err: &OperationError{Code: 5}, want: ErrNotFound},
+		{name: "operation already exists", err: &OperationError{Code: 6}, want: ErrAlreadyExists},
+		{name: "operation permission denied", err: &OperationError{Code: 7}, want: ErrPermissionDenied},
+		{name: "operation resource exhausted", err: &OperationError{Code: 8}, want: ErrResourceExhausted},
+		{name: "operation failed precondition", err: &OperationError{Code: 9}, want: ErrFailedPrecondition},
+		{name: "operation internal", err: &OperationError{Code: 13}, want: nil},
+		{name: "other error", err: errors.New("connection refused"), want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classifyError(tt.err); got != tt.want {
+				t.Errorf("classifyError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewAPIError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantKind error
+	}{
+		{name: "classified", err: &googleapi.Error{Code: http.StatusNotFound}, wantKind: ErrNotFound},
+		{name: "unclassified", err: &googleapi.Error{Code: http.StatusInternalServerError}, wantKind: nil},
+		{name: "operation timeout", err: &OperationTimeoutError{}, wantKind: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := newAPIError("get engine", tt.err)
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("newAPIError() = %T, want *APIError", err)
+			}
+			if apiErr.Kind != tt.wantKind {
+				t.Errorf("Kind = %v, want %v", apiErr.Kind, tt.wantKind)
+			}
+			if tt.wantKind != nil && !errors.Is(err, tt.wantKind) {
+				t.Errorf("errors.Is(err, %v) = false", tt.wantKind)
+			}
+			if !errors.Is(err, tt.err) {
+				t.Errorf("the underlying error is not reachable through errors.Is")
+			}
+		})
+	}
+}
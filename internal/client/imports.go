@@ -0,0 +1,283 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/api/discoveryengine/v1"
+)
+
+// ImportResult describes a finished document import operation
+type ImportResult struct {
+	OperationName string `json:"operation_name"`
+	SuccessCount  int64  `json:"success_count"`
+	FailureCount  int64  `json:"failure_count"`
+	TotalCount    int64  `json:"total_count"`
+	// ErrorSamples holds messages of a sample of the documents that failed to import
+	ErrorSamples []string `json:"error_samples,omitempty"`
+	// ErrorGCSPrefix is the GCS location the full list of import errors was written to
+	ErrorGCSPrefix string `json:"error_gcs_prefix,omitempty"`
+}
+
+// ImportSource selects where documents are imported from. Exactly one source
+// must be set.
+type ImportSource struct {
+	GCS       *GCSSource
+	BigQuery  *BigQuerySource
+	CloudSQL  *CloudSQLSource
+	Spanner   *SpannerSource
+	AlloyDB   *AlloyDBSource
+	Firestore *FirestoreSource
+	Bigtable  *BigtableSource
+
+	// IDField names the field holding the ID of each document in structured
+	// sources. AutoGenerateIDs derives IDs from the document payload instead.
+	IDField         string
+	AutoGenerateIDs bool
+}
+
+// GCSSource imports documents from Cloud Storage
+type GCSSource struct {
+	InputURIs  []string
+	DataSchema string
+}
+
+// BigQuerySource imports documents from a BigQuery table
+type BigQuerySource struct {
+	// ProjectID defaults to the project the client is configured for
+	ProjectID string
+	DatasetID string
+	TableID   string
+	// PartitionDate selects a partition of a time partitioned table, as YYYY-MM-DD
+	PartitionDate string
+	DataSchema    string
+}
+
+// CloudSQLSource imports documents from a Cloud SQL table
+type CloudSQLSource struct {
+	// ProjectID defaults to the project the client is configured for
+	ProjectID  string
+	InstanceID string
+	DatabaseID string
+	TableID    string
+	// GCSStagingDir is the Cloud Storage directory the table is exported to
+	GCSStagingDir string
+	// Offload runs the export as a serverless export
+	Offload bool
+}
+
+// SpannerSource imports documents from a Spanner table
+type SpannerSource struct {
+	// ProjectID defaults to the project the client is configured for
+	ProjectID       string
+	InstanceID      string
+	DatabaseID      string
+	TableID         string
+	EnableDataBoost bool
+}
+
+// AlloyDBSource imports documents from an AlloyDB table
+type AlloyDBSource struct {
+	// ProjectID defaults to the project the client is configured for
+	ProjectID  string
+	LocationID string
+	ClusterID  string
+	DatabaseID string
+	TableID    string
+	// GCSStagingDir is the Cloud Storage directory the table is exported to
+	GCSStagingDir string
+}
+
+// FirestoreSource imports documents from a Firestore collection
+type FirestoreSource struct {
+	// ProjectID defaults to the project the client is configured for
+	ProjectID    string
+	DatabaseID   string
+	CollectionID string
+	// GCSStagingDir is the Cloud Storage directory the collection is exported to
+	GCSStagingDir string
+}
+
+// BigtableSource imports documents from a Bigtable table
+type BigtableSource struct {
+	// ProjectID defaults to the project the client is configured for
+	ProjectID  string
+	InstanceID string
+	TableID    string
+	// KeyFieldName is the document field the row key is stored in
+	KeyFieldName string
+	// Families describes the column families to import by family name; other
+	// families are ignored
+	Families map[string]BigtableColumnFamily
+}
+
+// BigtableColumnFamily describes how the columns of a Bigtable column family
+// are converted to document fields
+type BigtableColumnFamily struct {
+	FieldName string
+	Type      string
+	Encoding  string
+	Columns   []BigtableColumn
+}
+
+// BigtableColumn describes how a Bigtable column is converted to a document field
+type BigtableColumn struct {
+	Qualifier string
+	FieldName string
+	Type      string
+	Encoding  string
+}
+
+// ImportDocuments imports documents from source into a branch of a data store
+// and waits for the import to finish. When errorGCSPrefix is set, an error is
+// written below it for every document that failed.
+func (c *GeminiClient) ImportDocuments(ctx context.Context, dataStoreName, branch string, source *ImportSource, reconciliationMode, errorGCSPrefix string) (*ImportResult, error) {
+	branchName := fmt.Sprintf("%s/branches/%s", dataStoreName, branch)
+
+	importConfig := &discoveryengine.GoogleCloudDiscoveryengineV1ImportDocumentsRequest{
+		ReconciliationMode: reconciliationMode,
+	}
+	if err := c.setImportSource(importConfig, source); err != nil {
+		return nil, err
+	}
+	if errorGCSPrefix != "" {
+		importConfig.ErrorConfig = &discoveryengine.GoogleCloudDiscoveryengineV1ImportErrorConfig{
+			GcsPrefix: errorGCSPrefix,
+		}
+	}
+
+	importCall := c.service.Projects.Locations.DataStores.Branches.Documents.Import(branchName, importConfig)
+	operation, err := importCall.Context(ctx).Do()
+	if err != nil {
+		return nil, newAPIError("import documents", err)
+	}
+
+	var response discoveryengine.GoogleCloudDiscoveryengineV1ImportDocumentsResponse
+	var metadata discoveryengine.GoogleCloudDiscoveryengineV1ImportDocumentsMetadata
+	if err := c.waitForOperation(ctx, operation, &response, &metadata); err != nil {
+		return nil, newAPIError("import documents", err)
+	}
+
+	result := &ImportResult{
+		OperationName: operation.Name,
+		SuccessCount:  metadata.SuccessCount,
+		FailureCount:  metadata.FailureCount,
+		TotalCount:    metadata.TotalCount,
+	}
+	if response.ErrorConfig != nil {
+		result.ErrorGCSPrefix = response.ErrorConfig.GcsPrefix
+	}
+	for _, sample := range response.ErrorSamples {
+		if sample != nil && sample.Message != "" {
+			result.ErrorSamples = append(result.ErrorSamples, sample.Message)
+		}
+	}
+
+	return result, nil
+}
+
+// setImportSource sets the source of an import request
+func (c *GeminiClient) setImportSource(request *discoveryengine.GoogleCloudDiscoveryengineV1ImportDocumentsRequest, source *ImportSource) error {
+	switch {
+	case source != nil && source.GCS != nil:
+		request.GcsSource = &discoveryengine.GoogleCloudDiscoveryengineV1GcsSource{
+			InputUris:  source.GCS.InputURIs,
+			DataSchema: source.GCS.DataSchema,
+		}
+	case source != nil && source.BigQuery != nil:
+		request.BigquerySource = &discoveryengine.GoogleCloudDiscoveryengineV1BigQuerySource{
+			ProjectId:  valueOrDefault(source.BigQuery.ProjectID, c.config.ProjectID),
+			DatasetId:  source.BigQuery.DatasetID,
+			TableId:    source.BigQuery.TableID,
+			DataSchema: source.BigQuery.DataSchema,
+		}
+		if source.BigQuery.PartitionDate != "" {
+			date, err := time.Parse("2006-01-02", source.BigQuery.PartitionDate)
+			if err != nil {
+				return fmt.Errorf("import documents: invalid partition date %q, expected YYYY-MM-DD", source.BigQuery.PartitionDate)
+			}
+			request.BigquerySource.PartitionDate = &discoveryengine.GoogleTypeDate{
+				Year:  int64(date.Year()),
+				Month: int64(date.Month()),
+				Day:   int64(date.Day()),
+			}
+		}
+	case source != nil && source.CloudSQL != nil:
+		request.CloudSqlSource = &discoveryengine.GoogleCloudDiscoveryengineV1CloudSqlSource{
+			ProjectId:     valueOrDefault(source.CloudSQL.ProjectID, c.config.ProjectID),
+			InstanceId:    source.CloudSQL.InstanceID,
+			DatabaseId:    source.CloudSQL.DatabaseID,
+			TableId:       source.CloudSQL.TableID,
+			GcsStagingDir: source.CloudSQL.GCSStagingDir,
+			Offload:       source.CloudSQL.Offload,
+		}
+	case source != nil && source.Spanner != nil:
+		request.SpannerSource = &discoveryengine.GoogleCloudDiscoveryengineV1SpannerSource{
+			ProjectId:       valueOrDefault(source.Spanner.ProjectID, c.config.ProjectID),
+			InstanceId:      source.Spanner.InstanceID,
+			DatabaseId:      source.Spanner.DatabaseID,
+			TableId:         source.Spanner.TableID,
+			EnableDataBoost: source.Spanner.EnableDataBoost,
+		}
+	case source != nil && source.AlloyDB != nil:
+		request.AlloyDbSource = &discoveryengine.GoogleCloudDiscoveryengineV1AlloyDbSource{
+			ProjectId:     valueOrDefault(source.AlloyDB.ProjectID, c.config.ProjectID),
+			LocationId:    source.AlloyDB.LocationID,
+			ClusterId:     source.AlloyDB.ClusterID,
+			DatabaseId:    source.AlloyDB.DatabaseID,
+			TableId:       source.AlloyDB.TableID,
+			GcsStagingDir: source.AlloyDB.GCSStagingDir,
+		}
+	case source != nil && source.Firestore != nil:
+		request.FirestoreSource = &discoveryengine.GoogleCloudDiscoveryengineV1FirestoreSource{
+			ProjectId:     valueOrDefault(source.Firestore.ProjectID, c.config.ProjectID),
+			DatabaseId:    source.Firestore.DatabaseID,
+			CollectionId:  source.Firestore.CollectionID,
+			GcsStagingDir: source.Firestore.GCSStagingDir,
+		}
+	case source != nil && source.Bigtable != nil:
+		request.BigtableSource = toAPIBigtableSource(source.Bigtable, c.config.ProjectID)
+	default:
+		return fmt.Errorf("import documents: no source given")
+	}
+
+	request.IdField = source.IDField
+	request.AutoGenerateIds = source.AutoGenerateIDs
+
+	return nil
+}
+
+// toAPIBigtableSource converts a BigtableSource to its Discovery Engine API form
+func toAPIBigtableSource(source *BigtableSource, defaultProjectID string) *discoveryengine.GoogleCloudDiscoveryengineV1BigtableSource {
+	result := &discoveryengine.GoogleCloudDiscoveryengineV1BigtableSource{
+		ProjectId:  valueOrDefault(source.ProjectID, defaultProjectID),
+		InstanceId: source.InstanceID,
+		TableId:    source.TableID,
+		BigtableOptions: &discoveryengine.GoogleCloudDiscoveryengineV1BigtableOptions{
+			KeyFieldName: source.KeyFieldName,
+		},
+	}
+
+	if len(source.Families) > 0 {
+		result.BigtableOptions.Families = make(map[string]discoveryengine.GoogleCloudDiscoveryengineV1BigtableOptionsBigtableColumnFamily)
+		for name, family := range source.Families {
+			apiFamily := discoveryengine.GoogleCloudDiscoveryengineV1BigtableOptionsBigtableColumnFamily{
+				FieldName: family.FieldName,
+				Type:      family.Type,
+				Encoding:  family.Encoding,
+			}
+			for _, column := range family.Columns {
+				apiFamily.Columns = append(apiFamily.Columns, &discoveryengine.GoogleCloudDiscoveryengineV1BigtableOptionsBigtableColumn{
+					Qualifier: column.Qualifier,
+					FieldName: column.FieldName,
+					Type:      column.Type,
+					Encoding:  column.Encoding,
+				})
+			}
+			result.BigtableOptions.Families[name] = apiFamily
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,127 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/api/discoveryengine/v1"
+)
+
+const (
+	// operationInitialPollInterval is the delay before the first status check of an operation
+	operationInitialPollInterval = 2 * time.Second
+	// operationMaxPollInterval caps the delay between status checks
+	operationMaxPollInterval = 30 * time.Second
+	// operationPollMultiplier grows the delay between consecutive status checks
+	operationPollMultiplier = 1.5
+)
+
+// OperationError is returned when a long-running operation completes with an error
+type OperationError struct {
+	Operation string
+	Code      int64
+	Message   string
+	Details   []string
+}
+
+func (e *OperationError) Error() string {
+	msg := fmt.Sprintf("operation %s failed (code %d): %s", e.Operation, e.Code, e.Message)
+	if len(e.Details) > 0 {
+		msg += fmt.Sprintf(" [details: %s]", strings.Join(e.Details, "; "))
+	}
+	return msg
+}
+
+// OperationTimeoutError is returned when the caller's deadline expires before a
+// long-running operation completes. The operation may still finish server-side.
+type OperationTimeoutError struct {
+	Operation string
+	Err       error
+}
+
+func (e *OperationTimeoutError) Error() string {
+	return fmt.Sprintf("timed out waiting for operation %s to complete; it may still be running and can be inspected with `gcloud` or the Discovery Engine operations API", e.Operation)
+}
+
+func (e *OperationTimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// waitForOperation polls a long-running operation until it is done, backing off
+// exponentially between checks. When the operation succeeds its response and
+// metadata are decoded into response and metadata, either of which may be nil.
+func (c *GeminiClient) waitForOperation(ctx context.Context, op *discoveryengine.GoogleLongrunningOperation, response, metadata interface{}) error {
+	if op == nil {
+		return fmt.Errorf("no operation returned by the API")
+	}
+
+	interval := operationInitialPollInterval
+	for !op.Done {
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return operationContextError(op.Name, ctx.Err())
+		case <-timer.C:
+		}
+
+		current, err := c.service.Projects.Locations.Operations.Get(op.Name).Context(ctx).Do()
+		if err != nil {
+			if ctx.Err() != nil {
+				return operationContextError(op.Name, ctx.Err())
+			}
+			return fmt.Errorf("failed to check status of operation %s: %w", op.Name, err)
+		}
+		op = current
+
+		interval = time.Duration(float64(interval) * operationPollMultiplier)
+		if interval > operationMaxPollInterval {
+			interval = operationMaxPollInterval
+		}
+	}
+
+	if metadata != nil && len(op.Metadata) > 0 {
+		if err := json.Unmarshal(op.Metadata, metadata); err != nil {
+			return fmt.Errorf("failed to decode metadata of operation %s: %w", op.Name, err)
+		}
+	}
+
+	if op.Error != nil {
+		return newOperationError(op.Name, op.Error)
+	}
+
+	if response != nil && len(op.Response) > 0 {
+		if err := json.Unmarshal(op.Response, response); err != nil {
+			return fmt.Errorf("failed to decode response of operation %s: %w", op.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// operationContextError reports why waiting for an operation was abandoned
+func operationContextError(operationName string, err error) error {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return &OperationTimeoutError{Operation: operationName, Err: err}
+	}
+	return fmt.Errorf("stopped waiting for operation %s: %w", operationName, err)
+}
+
+// newOperationError converts the status of a failed operation to an OperationError
+func newOperationError(operationName string, status *discoveryengine.GoogleRpcStatus) *OperationError {
+	result := &OperationError{
+		Operation: operationName,
+		Code:      status.Code,
+		Message:   status.Message,
+	}
+
+	for _, detail := range status.Details {
+		result.Details = append(result.Details, string(detail))
+	}
+
+	return result
+}
@@ -0,0 +1,83 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/api/discoveryengine/v1"
+	"google.golang.org/api/iterator"
+)
+
+// ListOptions controls paging and filtering of list calls
+type ListOptions struct {
+	// PageSize is the maximum number of items fetched per request; zero uses the API default
+	PageSize int64
+	// Filter is an optional API filter expression. The documents API does not
+	// support filtering, so it must be empty when listing documents.
+	Filter string
+}
+
+// DocumentIterator walks the documents of a branch one page at a time, so
+// callers can stream large branches without holding them in memory
+type DocumentIterator struct {
+	ctx       context.Context
+	call      *discoveryengine.ProjectsLocationsDataStoresBranchesDocumentsListCall
+	buffer    []*discoveryengine.GoogleCloudDiscoveryengineV1Document
+	pageToken string
+	started   bool
+	err       error
+}
+
+// Documents returns an iterator over the documents of a data store branch
+func (c *GeminiClient) Documents(ctx context.Context, dataStoreName, branch string, opts *ListOptions) *DocumentIterator {
+	branchName := fmt.Sprintf("%s/branches/%s", dataStoreName, branch)
+
+	it := &DocumentIterator{
+		ctx:  ctx,
+		call: c.service.Projects.Locations.DataStores.Branches.Documents.List(branchName),
+	}
+	if opts != nil {
+		if opts.Filter != "" {
+			it.err = fmt.Errorf("filtering is not supported when listing documents")
+		}
+		if opts.PageSize > 0 {
+			it.call.PageSize(opts.PageSize)
+		}
+	}
+
+	return it
+}
+
+// Next returns the next document in the branch. It returns iterator.Done once
+// every document has been returned.
+func (it *DocumentIterator) Next() (*Document, error) {
+	for len(it.buffer) == 0 {
+		if it.err != nil {
+			return nil, it.err
+		}
+		if it.started && it.pageToken == "" {
+			it.err = iterator.Done
+			return nil, it.err
+		}
+		it.fetch()
+	}
+
+	doc := it.buffer[0]
+	it.buffer = it.buffer[1:]
+	return convertDocument(doc), nil
+}
+
+// fetch loads the next page of documents into the buffer
+func (it *DocumentIterator) fetch() {
+	it.started = true
+	it.call.PageToken(it.pageToken)
+
+	response, err := it.call.Context(it.ctx).Do()
+	if err != nil {
+		it.err = newAPIError("list documents", err)
+		return
+	}
+
+	it.buffer = response.Documents
+	it.pageToken = response.NextPageToken
+}
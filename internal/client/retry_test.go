@@ -0,0 +1,206 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestShouldRetry(t *testing.T) {
+	transport := newRetryTransport(http.DefaultTransport, DefaultMaxRetries, 0).(*retryTransport)
+	networkErr := errors.New("connection reset by peer")
+
+	tests := []struct {
+		name   string
+		method string
+		status int
+		err    error
+		want   bool
+	}{
+		{name: "GET 429", method: http.MethodGet, status: http.StatusTooManyRequests, want: true},
+		{name: "POST 429", method: http.MethodPost, status: http.StatusTooManyRequests, want: true},
+		{name: "GET 503", method: http.MethodGet, status: http.StatusServiceUnavailable, want: true},
+		{name: "POST 503", method: http.MethodPost, status: http.StatusServiceUnavailable, want: true},
+		{name: "GET 500", method: http.MethodGet, status: http.StatusInternalServerError, want: true},
+		{name: "PATCH 502", method: http.MethodPatch, status: http.StatusBadGateway, want: true},
+		{name: "DELETE 504", method: http.MethodDelete, status: http.StatusGatewayTimeout, want: true},
+		{name: "POST 500", method: http.MethodPost, status: http.StatusInternalServerError, want: false},
+		{name: "POST 502", method: http.MethodPost, status: http.StatusBadGateway, want: false},
+		{name: "POST 504", method: http.MethodPost, status: http.StatusGatewayTimeout, want: false},
+		{name: "GET 200", method: http.MethodGet, status: http.StatusOK, want: false},
+		{name: "GET 400", method: http.MethodGet, status: http.StatusBadRequest, want: false},
+		{name: "GET 404", method: http.MethodGet, status: http.StatusNotFound, want: false},
+		{name: "GET 501", method: http.MethodGet, status: http.StatusNotImplemented, want: false},
+		{name: "GET network error", method: http.MethodGet, err: networkErr, want: true},
+		{name: "PUT network error", method: http.MethodPut, err: networkErr, want: true},
+		{name: "POST network error", method: http.MethodPost, err: networkErr, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, "https://example.com", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var resp *http.Response
+			if tt.err == nil {
+				resp = &http.Response{StatusCode: tt.status, Header: http.Header{}}
+			}
+
+			if got := transport.shouldRetry(req, resp, tt.err); got != tt.want {
+				t.Errorf("shouldRetry() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShouldRetryRequestState(t *testing.T) {
+	transport := newRetryTransport(http.DefaultTransport, DefaultMaxRetries, 0).(*retryTransport)
+	unavailable := &http.Response{StatusCode: http.StatusServiceUnavailable, Header: http.Header{}}
+
+	tests := []struct {
+		name    string
+		request func(t *testing.T) *http.Request
+		want    bool
+	}{
+		{
+			name: "replayable body",
+			request: func(t *testing.T) *http.Request {
+				req, err := http.NewRequest(http.MethodPost, "https://example.com", strings.NewReader("{}"))
+				if err != nil {
+					t.Fatal(err)
+				}
+				return req
+			},
+			want: true,
+		},
+		{
+			name: "non-replayable body",
+			request: func(t *testing.T) *http.Request {
+				req, err := http.NewRequest(http.MethodPost, "https://example.com", io.NopCloser(strings.NewReader("{}")))
+				if err != nil {
+					t.Fatal(err)
+				}
+				return req
+			},
+			want: false,
+		},
+		{
+			name: "cancelled context",
+			request: func(t *testing.T) *http.Request {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://example.com", nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return req
+			},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := transport.shouldRetry(tt.request(t), unavailable, nil); got != tt.want {
+				t.Errorf("shouldRetry() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{name: "empty", value: "", want: 0, wantOK: false},
+		{name: "seconds", value: "7", want: 7 * time.Second, wantOK: true},
+		{name: "zero seconds", value: "0", want: 0, wantOK: true},
+		{name: "negative seconds", value: "-1", want: 0, wantOK: false},
+		{name: "past date", value: "Wed, 21 Oct 2015 07:28:00 GMT", want: 0, wantOK: true},
+		{name: "invalid", value: "soon", want: 0, wantOK: false},
+		{name: "fractional seconds", value: "1.5", want: 0, wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseRetryAfter(tt.value)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("parseRetryAfter(%q) = %v, %v, want %v, %v", tt.value, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestParseRetryAfterFutureDate(t *testing.T) {
+	value := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+
+	got, ok := parseRetryAfter(value)
+	if !ok {
+		t.Fatalf("parseRetryAfter(%q) was not accepted", value)
+	}
+	// HTTP dates have a resolution of one second
+	if got <= 58*time.Second || got > time.Minute {
+		t.Errorf("parseRetryAfter(%q) = %v, want about a minute", value, got)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxBackoff  time.Duration
+		attempt     int
+		wantCeiling time.Duration
+	}{
+		{name: "first attempt", maxBackoff: 30 * time.Second, attempt: 0, wantCeiling: time.Second},
+		{name: "third attempt", maxBackoff: 30 * time.Second, attempt: 2, wantCeiling: 4 * time.Second},
+		{name: "capped by max backoff", maxBackoff: 30 * time.Second, attempt: 5, wantCeiling: 30 * time.Second},
+		{name: "shift overflow", maxBackoff: 30 * time.Second, attempt: 100, wantCeiling: 30 * time.Second},
+		{name: "default max backoff", maxBackoff: 0, attempt: 10, wantCeiling: DefaultRetryMaxBackoff},
+		{name: "small max backoff", maxBackoff: 10 * time.Millisecond, attempt: 0, wantCeiling: 10 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := newRetryTransport(http.DefaultTransport, DefaultMaxRetries, tt.maxBackoff).(*retryTransport)
+			for range 100 {
+				got := transport.backoff(tt.attempt, nil)
+				if got <= 0 || got > tt.wantCeiling {
+					t.Fatalf("backoff(%d) = %v, want within (0, %v]", tt.attempt, got, tt.wantCeiling)
+				}
+			}
+		})
+	}
+}
+
+func TestBackoffRetryAfter(t *testing.T) {
+	transport := newRetryTransport(http.DefaultTransport, DefaultMaxRetries, time.Second).(*retryTransport)
+
+	tests := []struct {
+		name       string
+		retryAfter string
+		want       time.Duration
+	}{
+		{name: "takes precedence over the ceiling", retryAfter: "120", want: 2 * time.Minute},
+		{name: "zero", retryAfter: "0", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+			resp.Header.Set("Retry-After", tt.retryAfter)
+
+			if got := transport.backoff(0, resp); got != tt.want {
+				t.Errorf("backoff() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
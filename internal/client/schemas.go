@@ -0,0 +1,166 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"google.golang.org/api/discoveryengine/v1"
+)
+
+// DefaultSchemaID is the ID of the schema every data store is created with
+const DefaultSchemaID = "default_schema"
+
+// Schema represents the schema of a data store
+type Schema struct {
+	Name string `json:"name"`
+	// JSONSchema is the schema as a JSON Schema document
+	JSONSchema string `json:"jsonSchema,omitempty"`
+}
+
+// FieldConfig describes how a schema field is indexed and served, as declared
+// by the annotations of its property in the JSON schema
+type FieldConfig struct {
+	FieldPath          string `json:"fieldPath"`
+	FieldType          string `json:"fieldType"`
+	Retrievable        bool   `json:"retrievable,omitempty"`
+	Searchable         bool   `json:"searchable,omitempty"`
+	Indexable          bool   `json:"indexable,omitempty"`
+	DynamicFacetable   bool   `json:"dynamicFacetable,omitempty"`
+	Completable        bool   `json:"completable,omitempty"`
+	KeyPropertyMapping string `json:"keyPropertyMapping,omitempty"`
+}
+
+// GetSchema gets a data store schema
+func (c *GeminiClient) GetSchema(ctx context.Context, schemaName string) (*Schema, error) {
+	call := c.service.Projects.Locations.Collections.DataStores.Schemas.Get(schemaName)
+	schema, err := call.Context(ctx).Do()
+	if err != nil {
+		return nil, newAPIError("get schema", err)
+	}
+
+	return convertSchema(schema), nil
+}
+
+// UpdateSchema replaces a data store schema with jsonSchema, creating the schema
+// if it does not exist, and waits for the update to complete
+func (c *GeminiClient) UpdateSchema(ctx context.Context, schemaName, jsonSchema string) (*Schema, error) {
+	call := c.service.Projects.Locations.Collections.DataStores.Schemas.Patch(schemaName,
+		&discoveryengine.GoogleCloudDiscoveryengineV1Schema{
+			Name:       schemaName,
+			JsonSchema: jsonSchema,
+		})
+	call.AllowMissing(true)
+
+	operation, err := call.Context(ctx).Do()
+	if err != nil {
+		return nil, newAPIError("update schema", err)
+	}
+
+	var schema discoveryengine.GoogleCloudDiscoveryengineV1Schema
+	if err := c.waitForOperation(ctx, operation, &schema, nil); err != nil {
+		return nil, newAPIError("update schema", err)
+	}
+
+	if schema.Name == "" {
+		// The operation response may be empty; read the schema back instead
+		return c.GetSchema(ctx, schemaName)
+	}
+
+	return convertSchema(&schema), nil
+}
+
+// DeleteSchema deletes a data store schema and waits for the deletion to complete
+func (c *GeminiClient) DeleteSchema(ctx context.Context, schemaName string) error {
+	call := c.service.Projects.Locations.Collections.DataStores.Schemas.Delete(schemaName)
+	operation, err := call.Context(ctx).Do()
+	if err != nil {
+		return newAPIError("delete schema", err)
+	}
+
+	if err := c.waitForOperation(ctx, operation, nil, nil); err != nil {
+		return newAPIError("delete schema", err)
+	}
+
+	return nil
+}
+
+// FieldConfigs returns the config of every field declared in the schema,
+// sorted by field path. Nested fields are named with dotted paths.
+func (s *Schema) FieldConfigs() ([]FieldConfig, error) {
+	if s.JSONSchema == "" {
+		return nil, nil
+	}
+
+	var root map[string]interface{}
+	if err := json.Unmarshal([]byte(s.JSONSchema), &root); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON schema of %s: %w", s.Name, err)
+	}
+
+	var configs []FieldConfig
+	collectFieldConfigs(root, "", &configs)
+	sort.Slice(configs, func(i, j int) bool {
+		return configs[i].FieldPath < configs[j].FieldPath
+	})
+
+	return configs, nil
+}
+
+// collectFieldConfigs appends the config of every property below a JSON schema node
+func collectFieldConfigs(node map[string]interface{}, prefix string, configs *[]FieldConfig) {
+	properties, _ := node["properties"].(map[string]interface{})
+	for name, value := range properties {
+		property, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		path := name
+		if prefix != "" {
+			path = prefix + "." + name
+		}
+
+		// Arrays are described by their items
+		fieldType, _ := property["type"].(string)
+		described := property
+		if items, ok := property["items"].(map[string]interface{}); ok && fieldType == "array" {
+			described = items
+			if itemType, ok := items["type"].(string); ok {
+				fieldType = "array<" + itemType + ">"
+			}
+		}
+
+		config := FieldConfig{
+			FieldPath:        path,
+			FieldType:        fieldType,
+			Retrievable:      described["retrievable"] == true,
+			Searchable:       described["searchable"] == true,
+			Indexable:        described["indexable"] == true,
+			DynamicFacetable: described["dynamicFacetable"] == true,
+			Completable:      described["completable"] == true,
+		}
+		config.KeyPropertyMapping, _ = described["keyPropertyMapping"].(string)
+		*configs = append(*configs, config)
+
+		if strings.HasPrefix(fieldType, "object") || strings.HasPrefix(fieldType, "array<object") {
+			collectFieldConfigs(described, path, configs)
+		}
+	}
+}
+
+// convertSchema converts a Discovery Engine API schema to our Schema struct. The
+// API returns either a JSON schema or an equivalent struct schema.
+func convertSchema(schema *discoveryengine.GoogleCloudDiscoveryengineV1Schema) *Schema {
+	result := &Schema{
+		Name:       schema.Name,
+		JSONSchema: schema.JsonSchema,
+	}
+
+	if result.JSONSchema == "" && len(schema.StructSchema) > 0 {
+		result.JSONSchema = string(schema.StructSchema)
+	}
+
+	return result
+}
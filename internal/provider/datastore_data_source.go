@@ -81,11 +81,11 @@ func (d *dataStoreDataSource) Read(ctx context.Context, req datasource.ReadReque
 		model.DataStoreID.ValueString())
 
 	// Read the data store
-	dataStore, err := d.client.GetDataStoreDetails(dataStoreName)
+	dataStore, err := d.client.GetDataStoreDetails(ctx, dataStoreName)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading data store",
-			fmt.Sprintf("Failed to read data store: %v", err),
+			clientErrorDetail(err),
 		)
 		return
 	}
@@ -99,4 +99,3 @@ func (d *dataStoreDataSource) Read(ctx context.Context, req datasource.ReadReque
 	diags = resp.State.Set(ctx, model)
 	resp.Diagnostics.Append(diags...)
 }
-
@@ -2,25 +2,93 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"regexp"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	client "github.com/vb140772/terraform-provider-gemctl/internal/client"
 )
 
+// Ensure NewDataStoreResource returns a resource with the correct interface implementation
+var (
+	_ resource.Resource                = &dataStoreResource{}
+	_ resource.ResourceWithImportState = &dataStoreResource{}
+	_ resource.ResourceWithModifyPlan  = &dataStoreResource{}
+)
+
+const (
+	defaultDataStoreCreateTimeout = 60 * time.Minute
+	defaultDataStoreReadTimeout   = 5 * time.Minute
+	defaultDataStoreUpdateTimeout = 60 * time.Minute
+	defaultDataStoreDeleteTimeout = 20 * time.Minute
+)
+
+var (
+	// gcsURIPattern matches Cloud Storage object paths and wildcard patterns
+	gcsURIPattern = regexp.MustCompile(`^gs://[a-z0-9][a-z0-9._-]*[a-z0-9]/.+$`)
+	// gcsPrefixPattern matches Cloud Storage buckets and directories
+	gcsPrefixPattern = regexp.MustCompile(`^gs://[a-z0-9][a-z0-9._-]*[a-z0-9](/.*)?$`)
+)
+
+const (
+	gcsURIPatternMessage = "must be a Cloud Storage URI of the form gs://bucket/path, optionally with * wildcards"
+
+	// maxGCSInputURIs is the largest number of input URIs accepted by a single import
+	maxGCSInputURIs = 100
+
+	// dataStoreAdoptedKey is the private state key marking a data store adopted
+	// with terraform import whose import source has not been recorded yet
+	dataStoreAdoptedKey = "adopted"
+	// dataStoreImportPendingKey is the private state key marking a data store
+	// whose import on create failed too many documents and must be run again
+	dataStoreImportPendingKey = "import_pending"
+)
+
 type dataStoreResource struct {
 	client *client.GeminiClient
 }
 
 type dataStoreResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	DataStoreID  types.String `tfsdk:"data_store_id"`
-	DisplayName  types.String `tfsdk:"display_name"`
-	GCSUri       types.String `tfsdk:"gcs_uri"`
-	Name         types.String `tfsdk:"name"`
+	ID                       types.String   `tfsdk:"id"`
+	DataStoreID              types.String   `tfsdk:"data_store_id"`
+	DisplayName              types.String   `tfsdk:"display_name"`
+	GCSUri                   types.String   `tfsdk:"gcs_uri"`
+	GCSUris                  types.List     `tfsdk:"gcs_uris"`
+	DataSchema               types.String   `tfsdk:"data_schema"`
+	ReconciliationMode       types.String   `tfsdk:"reconciliation_mode"`
+	IndustryVertical         types.String   `tfsdk:"industry_vertical"`
+	SolutionTypes            types.Set      `tfsdk:"solution_types"`
+	ContentConfig            types.String   `tfsdk:"content_config"`
+	AclEnabled               types.Bool     `tfsdk:"acl_enabled"`
+	DocumentProcessingConfig types.Object   `tfsdk:"document_processing_config"`
+	BigQuerySource           types.Object   `tfsdk:"bigquery_source"`
+	MaxImportFailures        types.Int64    `tfsdk:"max_import_failures"`
+	ImportErrorGCSPrefix     types.String   `tfsdk:"import_error_gcs_prefix"`
+	LastImport               types.Object   `tfsdk:"last_import"`
+	Name                     types.String   `tfsdk:"name"`
+	Timeouts                 timeouts.Value `tfsdk:"timeouts"`
 }
 
 func NewDataStoreResource(c *client.GeminiClient) resource.Resource {
@@ -33,30 +101,189 @@ func (r *dataStoreResource) Metadata(_ context.Context, req resource.MetadataReq
 	resp.TypeName = req.ProviderTypeName + "_data_store"
 }
 
-func (r *dataStoreResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *dataStoreResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Manages a data store in Google Gemini Enterprise. Data stores import content from GCS buckets and can be connected to search engines.",
+		MarkdownDescription: "Manages a data store in Google Gemini Enterprise. Data stores can optionally import content from GCS buckets or BigQuery tables and can be connected to search engines. " +
+			"A data store adopted with terraform import already holds its documents, so the first apply only records the configured source; later changes to it import as usual.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"data_store_id": schema.StringAttribute{
 				Required:    true,
 				Description: "Unique identifier for the data store",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"display_name": schema.StringAttribute{
 				Required:    true,
-				Description: "Display name for the data store",
+				Description: "Display name for the data store. Changes are applied in place.",
 			},
 			"gcs_uri": schema.StringAttribute{
-				Required:    true,
-				Description: "GCS URI to import data from (e.g., gs://bucket/path/*)",
+				Optional:    true,
+				Description: "GCS URI to import documents from (e.g., gs://bucket/path/*). Shorthand for a single entry in gcs_uris. When neither is set, an empty data store is created. Setting or changing it imports the documents at the new URI into the existing data store.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(gcsURIPattern, gcsURIPatternMessage),
+					stringvalidator.ConflictsWith(path.MatchRoot("gcs_uris")),
+				},
+			},
+			"gcs_uris": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "GCS URIs or patterns to import documents from (e.g., gs://bucket/path/*.jsonl), at most 100. Setting or changing them imports the matching documents into the existing data store.",
+				Validators: []validator.List{
+					listvalidator.SizeBetween(1, maxGCSInputURIs),
+					listvalidator.ValueStringsAre(
+						stringvalidator.RegexMatches(gcsURIPattern, gcsURIPatternMessage),
+						stringvalidator.LengthAtMost(2000),
+					),
+				},
+			},
+			"data_schema": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("document"),
+				Description: "Format of the imported files: document (one JSON Document per line), custom (custom JSON matching the data store schema), csv (CSV with a header matching the schema) or content (unstructured files such as PDF or HTML). custom and csv require the GENERIC industry vertical. Defaults to document. Changing it imports the configured URIs again. Not used by bigquery_source, which has its own data_schema.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("document", "custom", "csv", "content"),
+				},
+			},
+			"reconciliation_mode": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("INCREMENTAL"),
+				Description: "How imported documents are reconciled with existing ones: INCREMENTAL adds and updates documents, FULL also deletes documents missing from the source. Defaults to INCREMENTAL. Only applied when documents are imported.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("INCREMENTAL", "FULL"),
+				},
+			},
+			"industry_vertical": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(client.DefaultDataStoreIndustryVertical),
+				Description: "Industry vertical of the data store: GENERIC, MEDIA or HEALTHCARE_FHIR. Defaults to GENERIC. Changing this forces a new data store to be created.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("GENERIC", "MEDIA", "HEALTHCARE_FHIR"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"solution_types": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+				Default: setdefault.StaticValue(types.SetValueMust(types.StringType, []attr.Value{
+					types.StringValue(client.DefaultDataStoreSolutionType),
+				})),
+				Description: "Solutions the data store enrolls in: SOLUTION_TYPE_SEARCH, SOLUTION_TYPE_RECOMMENDATION, SOLUTION_TYPE_CHAT or SOLUTION_TYPE_GENERATIVE_CHAT. Defaults to [\"SOLUTION_TYPE_SEARCH\"]. Changing this forces a new data store to be created.",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.OneOf(
+						"SOLUTION_TYPE_SEARCH",
+						"SOLUTION_TYPE_RECOMMENDATION",
+						"SOLUTION_TYPE_CHAT",
+						"SOLUTION_TYPE_GENERATIVE_CHAT",
+					)),
+				},
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+			},
+			"content_config": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(client.DefaultDataStoreContentConfig),
+				Description: "Content config of the data store: NO_CONTENT, CONTENT_REQUIRED, PUBLIC_WEBSITE or GOOGLE_WORKSPACE. Defaults to CONTENT_REQUIRED. Changing this forces a new data store to be created.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("NO_CONTENT", "CONTENT_REQUIRED", "PUBLIC_WEBSITE", "GOOGLE_WORKSPACE"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
+			"acl_enabled": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Whether the source data carries ACL information. Only supported with the GENERIC industry vertical and a content config other than PUBLIC_WEBSITE. Defaults to false. Changing this forces a new data store to be created.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"max_import_failures": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Largest number of documents allowed to fail in an import. When more fail, the import is run again by the next apply: on update the apply reports an error, on create it reports a warning so the new data store is kept instead of being marked for recreation. Unset accepts any number of failures.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"import_error_gcs_prefix": schema.StringAttribute{
+				Optional:    true,
+				Description: "GCS prefix (e.g., gs://bucket/import-errors/) the errors of every document that fails to import are written to. Changing it does not trigger an import.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(gcsPrefixPattern, "must be a Cloud Storage location of the form gs://bucket or gs://bucket/path/"),
+				},
+			},
+			"last_import": importResultAttribute("Outcome of the most recent document import run by Terraform, or null when none has run"),
 			"name": schema.StringAttribute{
 				Computed:    true,
 				Description: "Full resource name of the data store",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"document_processing_config": documentProcessingConfigBlock(),
+			"bigquery_source": bigQuerySourceBlock(
+				"BigQuery table to import documents from instead of GCS. Setting or changing it imports the table into the existing data store.",
+				objectvalidator.ConflictsWith(path.MatchRoot("gcs_uri"), path.MatchRoot("gcs_uris")),
+			),
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// ModifyPlan keeps last_import known across plans that do not import documents
+func (r *dataStoreResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Creates always leave last_import unknown and destroys have nothing to plan
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state dataStoreResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	adopted, diags := privateFlag(ctx, req.Private, dataStoreAdoptedKey)
+	resp.Diagnostics.Append(diags...)
+	pending, diags := privateFlag(ctx, req.Private, dataStoreImportPendingKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A pending import plans last_import as unknown, so the next apply runs it
+	// again even when the configuration is unchanged
+	if pending && plan.hasImportSource() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("last_import"), types.ObjectUnknown(importResultAttrTypes))...)
+		return
+	}
+	if adopted || !importRequired(&plan, &state) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("last_import"), state.LastImport)...)
 	}
 }
 
@@ -68,24 +295,95 @@ func (r *dataStoreResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
-	// Create data store from GCS
-	result, err := r.client.CreateDataStoreFromGCS(
+	createTimeout, diags := model.Timeouts.Create(ctx, defaultDataStoreCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	var solutionTypes []string
+	diags = model.SolutionTypes.ElementsAs(ctx, &solutionTypes, false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	documentProcessingConfig, diags := documentProcessingConfigFromObject(ctx, model.DocumentProcessingConfig)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create the data store
+	result, err := r.client.CreateDataStore(
+		ctx,
 		model.DataStoreID.ValueString(),
 		model.DisplayName.ValueString(),
-		model.GCSUri.ValueString(),
-		"DATA_SCHEMA_DOCUMENT", // Default schema
-		"INCREMENTAL",           // Default reconciliation mode
+		&client.DataStoreOptions{
+			IndustryVertical:         model.IndustryVertical.ValueString(),
+			SolutionTypes:            solutionTypes,
+			ContentConfig:            model.ContentConfig.ValueString(),
+			AclEnabled:               model.AclEnabled.ValueBool(),
+			DocumentProcessingConfig: documentProcessingConfig,
+		},
 	)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating data store",
-			fmt.Sprintf("Failed to create data store: %v", err),
+			clientErrorDetail(err),
 		)
 		return
 	}
 
 	model.ID = types.StringValue(model.DataStoreID.ValueString())
 	model.Name = types.StringValue(result.DataStoreName)
+	model.LastImport = types.ObjectNull(importResultAttrTypes)
+
+	importSource, diags := model.importSource(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Import documents when a source is configured
+	if importSource != nil {
+		importResult, err := r.client.ImportDocuments(
+			ctx,
+			result.DataStoreName,
+			client.DefaultBranch,
+			importSource,
+			model.ReconciliationMode.ValueString(),
+			model.ImportErrorGCSPrefix.ValueString(),
+		)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error importing documents into data store",
+				clientErrorDetail(err),
+			)
+			// The data store exists even though the import failed; record it so
+			// Terraform marks it as tainted instead of orphaning it
+			diags = resp.State.Set(ctx, model)
+			resp.Diagnostics.Append(diags...)
+			return
+		}
+
+		model.LastImport, diags = importResultValue(ctx, importResult)
+		resp.Diagnostics.Append(diags...)
+
+		// An error would taint the new data store and recreate it on the next
+		// apply. Report the overrun as a warning and mark the import as pending
+		// instead, so only the import is run again.
+		if detail, exceeded := importFailuresError(importResult, model.MaxImportFailures); exceeded {
+			resp.Diagnostics.AddWarning("Too many documents failed to import",
+				detail+" The data store was created and the import will be run again by the next apply.")
+			resp.Diagnostics.Append(resp.Private.SetKey(ctx, dataStoreImportPendingKey, []byte("true"))...)
+		} else if detail, failed := importFailuresWarning(importResult); failed {
+			resp.Diagnostics.AddWarning("Some documents failed to import", detail)
+		}
+	}
 
 	diags = resp.State.Set(ctx, model)
 	resp.Diagnostics.Append(diags...)
@@ -99,6 +397,15 @@ func (r *dataStoreResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
+	readTimeout, diags := model.Timeouts.Read(ctx, defaultDataStoreReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	// Build the full data store name
 	dataStoreName := fmt.Sprintf("projects/%s/locations/%s/collections/%s/dataStores/%s",
 		r.client.Config().ProjectID,
@@ -107,18 +414,47 @@ func (r *dataStoreResource) Read(ctx context.Context, req resource.ReadRequest,
 		model.DataStoreID.ValueString())
 
 	// Read the data store
-	dataStore, err := r.client.GetDataStoreDetails(dataStoreName)
+	dataStore, err := r.client.GetDataStoreDetails(ctx, dataStoreName)
+	if errors.Is(err, client.ErrNotFound) {
+		// Deleted outside of Terraform; dropping it from state plans a recreate
+		resp.Diagnostics.AddWarning(
+			"Data store no longer exists",
+			fmt.Sprintf("The data store %q was not found and has been removed from the Terraform state. "+
+				"It was probably deleted outside of Terraform; the next apply will create it again.",
+				model.DataStoreID.ValueString()),
+		)
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading data store",
-			fmt.Sprintf("Failed to read data store: %v", err),
+			clientErrorDetail(err),
 		)
 		return
 	}
 
+	model.ID = types.StringValue(model.DataStoreID.ValueString())
 	model.DisplayName = types.StringValue(dataStore.DisplayName)
+	model.IndustryVertical = types.StringValue(dataStore.IndustryVertical)
+	model.ContentConfig = types.StringValue(dataStoreContentConfig(dataStore))
+	model.AclEnabled = types.BoolValue(dataStore.AclEnabled)
 	model.Name = types.StringValue(dataStore.Name)
 
+	model.DocumentProcessingConfig, diags = refreshDocumentProcessingConfig(ctx, model.DocumentProcessingConfig, dataStore.DocumentProcessingConfig)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if len(dataStore.SolutionTypes) > 0 {
+		model.SolutionTypes, diags = types.SetValueFrom(ctx, types.StringType, dataStore.SolutionTypes)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	diags = resp.State.Set(ctx, model)
 	resp.Diagnostics.Append(diags...)
 }
@@ -131,24 +467,113 @@ func (r *dataStoreResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
-	// For now, update recreates the data store with new config
-	// In a real implementation, you might want to check what changed
-	result, err := r.client.CreateDataStoreFromGCS(
-		model.DataStoreID.ValueString(),
-		model.DisplayName.ValueString(),
-		model.GCSUri.ValueString(),
-		"DATA_SCHEMA_DOCUMENT", // Default schema
-		"INCREMENTAL",           // Default reconciliation mode
-	)
+	updateTimeout, diags := model.Timeouts.Update(ctx, defaultDataStoreUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	var state dataStoreResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Build the full data store name
+	dataStoreName := fmt.Sprintf("projects/%s/locations/%s/collections/%s/dataStores/%s",
+		r.client.Config().ProjectID,
+		r.client.Config().Location,
+		r.client.Config().Collection,
+		model.DataStoreID.ValueString())
+
+	// Only send the fields that changed
+	update := &client.DataStoreUpdate{}
+	if !model.DisplayName.Equal(state.DisplayName) {
+		displayName := model.DisplayName.ValueString()
+		update.DisplayName = &displayName
+	}
+
+	// Update the data store in place
+	dataStore, err := r.client.PatchDataStore(ctx, dataStoreName, update)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating data store",
-			fmt.Sprintf("Failed to update data store: %v", err),
+			clientErrorDetail(err),
 		)
 		return
 	}
 
-	model.Name = types.StringValue(result.DataStoreName)
+	model.Name = types.StringValue(dataStore.Name)
+
+	importSource, diags := model.importSource(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	adopted, diags := privateFlag(ctx, req.Private, dataStoreAdoptedKey)
+	resp.Diagnostics.Append(diags...)
+	pending, diags := privateFlag(ctx, req.Private, dataStoreImportPendingKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A data store adopted with terraform import already holds its documents;
+	// the first configured source is recorded without importing them again
+	if adopted && importSource != nil {
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, dataStoreAdoptedKey, nil)...)
+	}
+
+	// A pending import is only retried while a source is configured
+	if pending && importSource == nil {
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, dataStoreImportPendingKey, nil)...)
+	}
+
+	// A new source or schema only imports documents into the default branch; the
+	// data store and its index are kept. Removing the source leaves the
+	// documents in place.
+	if !adopted && importSource != nil && (pending || importRequired(&model, &state)) {
+		importResult, err := r.client.ImportDocuments(
+			ctx,
+			dataStoreName,
+			client.DefaultBranch,
+			importSource,
+			model.ReconciliationMode.ValueString(),
+			model.ImportErrorGCSPrefix.ValueString(),
+		)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error importing documents into data store",
+				clientErrorDetail(err),
+			)
+			// Keep the previous source so the import is retried on the next apply
+			model.restoreImportSource(&state)
+			model.LastImport = state.LastImport
+			diags = resp.State.Set(ctx, model)
+			resp.Diagnostics.Append(diags...)
+			return
+		}
+
+		model.LastImport, diags = importResultValue(ctx, importResult)
+		resp.Diagnostics.Append(diags...)
+
+		if detail, exceeded := importFailuresError(importResult, model.MaxImportFailures); exceeded {
+			resp.Diagnostics.AddError("Too many documents failed to import", detail)
+			model.restoreImportSource(&state)
+		} else {
+			resp.Diagnostics.Append(resp.Private.SetKey(ctx, dataStoreImportPendingKey, nil)...)
+			if detail, failed := importFailuresWarning(importResult); failed {
+				resp.Diagnostics.AddWarning("Some documents failed to import", detail)
+			}
+		}
+	} else {
+		model.LastImport = state.LastImport
+	}
 
 	diags = resp.State.Set(ctx, model)
 	resp.Diagnostics.Append(diags...)
@@ -162,6 +587,15 @@ func (r *dataStoreResource) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 
+	deleteTimeout, diags := model.Timeouts.Delete(ctx, defaultDataStoreDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	// Build the full data store name
 	dataStoreName := fmt.Sprintf("projects/%s/locations/%s/collections/%s/dataStores/%s",
 		r.client.Config().ProjectID,
@@ -170,11 +604,11 @@ func (r *dataStoreResource) Delete(ctx context.Context, req resource.DeleteReque
 		model.DataStoreID.ValueString())
 
 	// Delete the data store
-	_, err := r.client.DeleteDataStore(dataStoreName)
+	err := r.client.DeleteDataStore(ctx, dataStoreName)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error deleting data store",
-			fmt.Sprintf("Failed to delete data store: %v", err),
+			clientErrorDetail(err),
 		)
 		return
 	}
@@ -182,3 +616,112 @@ func (r *dataStoreResource) Delete(ctx context.Context, req resource.DeleteReque
 	resp.State.RemoveResource(ctx)
 }
 
+func (r *dataStoreResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	dataStoreID, err := shortIDFromImportID(r.client.Config(), req.ID, "dataStores")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid data store import ID",
+			err.Error(),
+		)
+		return
+	}
+
+	// The API does not record which source a data store was loaded from, so
+	// gcs_uris and bigquery_source stay unset. The data store is marked as
+	// adopted so the next apply records the configured source instead of
+	// importing the documents a second time.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), dataStoreID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("data_store_id"), dataStoreID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("data_schema"), "document")...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("reconciliation_mode"), "INCREMENTAL")...)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, dataStoreAdoptedKey, []byte("true"))...)
+}
+
+// privateState reads provider-defined private state of a resource
+type privateState interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+}
+
+// privateFlag reports whether a flag such as dataStoreAdoptedKey is set in
+// the private state of a resource
+func privateFlag(ctx context.Context, private privateState, key string) (bool, diag.Diagnostics) {
+	value, diags := private.GetKey(ctx, key)
+	return len(value) > 0, diags
+}
+
+// dataStoreContentConfig returns the content config of a data store; the API
+// omits it for data stores without content
+func dataStoreContentConfig(dataStore *client.DataStore) string {
+	if dataStore.ContentConfig == "" || dataStore.ContentConfig == "CONTENT_CONFIG_UNSPECIFIED" {
+		return "NO_CONTENT"
+	}
+	return dataStore.ContentConfig
+}
+
+// importSource returns the configured import source, or nil when no import is configured
+func (m *dataStoreResourceModel) importSource(ctx context.Context) (*client.ImportSource, diag.Diagnostics) {
+	if !m.BigQuerySource.IsNull() {
+		source, diags := bigQuerySourceFromObject(ctx, m.BigQuerySource)
+		if source == nil {
+			return nil, diags
+		}
+		return &client.ImportSource{BigQuery: source}, diags
+	}
+
+	gcsURIs, diags := m.gcsInputURIs(ctx)
+	if len(gcsURIs) == 0 {
+		return nil, diags
+	}
+	return &client.ImportSource{
+		GCS: &client.GCSSource{
+			InputURIs:  gcsURIs,
+			DataSchema: m.DataSchema.ValueString(),
+		},
+	}, diags
+}
+
+// gcsInputURIs returns the configured GCS source URIs, or nil when no import is configured
+func (m *dataStoreResourceModel) gcsInputURIs(ctx context.Context) ([]string, diag.Diagnostics) {
+	if !m.GCSUri.IsNull() {
+		return []string{m.GCSUri.ValueString()}, nil
+	}
+	if m.GCSUris.IsNull() {
+		return nil, nil
+	}
+
+	var uris []string
+	diags := m.GCSUris.ElementsAs(ctx, &uris, false)
+	return uris, diags
+}
+
+// importRequired reports whether applying plan over state imports documents:
+// a source is configured and the source or its schema changed. data_schema
+// only applies to GCS sources; BigQuery carries its own. State written before
+// data_schema existed has no schema, which must not trigger an import on its
+// own. Unknown values count as changed.
+func importRequired(plan, state *dataStoreResourceModel) bool {
+	if !plan.hasImportSource() {
+		return false
+	}
+
+	if !plan.GCSUri.Equal(state.GCSUri) || !plan.GCSUris.Equal(state.GCSUris) ||
+		!plan.BigQuerySource.Equal(state.BigQuerySource) {
+		return true
+	}
+	return plan.BigQuerySource.IsNull() &&
+		!state.DataSchema.IsNull() && !plan.DataSchema.Equal(state.DataSchema)
+}
+
+// hasImportSource reports whether a GCS or BigQuery source is configured
+func (m *dataStoreResourceModel) hasImportSource() bool {
+	return !m.GCSUri.IsNull() || !m.GCSUris.IsNull() || !m.BigQuerySource.IsNull()
+}
+
+// restoreImportSource resets the import source to its value in state, so that
+// a failed import is attempted again on the next apply
+func (m *dataStoreResourceModel) restoreImportSource(state *dataStoreResourceModel) {
+	m.GCSUri = state.GCSUri
+	m.GCSUris = state.GCSUris
+	m.BigQuerySource = state.BigQuerySource
+	m.DataSchema = state.DataSchema
+}
@@ -0,0 +1,185 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	client "github.com/vb140772/terraform-provider-gemctl/internal/client"
+)
+
+type dataStoreSchemaDataSource struct {
+	client *client.GeminiClient
+}
+
+type dataStoreSchemaDataSourceModel struct {
+	DataStoreID  types.String         `tfsdk:"data_store_id"`
+	SchemaID     types.String         `tfsdk:"schema_id"`
+	Name         types.String         `tfsdk:"name"`
+	JSONSchema   jsontypes.Normalized `tfsdk:"json_schema"`
+	FieldConfigs types.List           `tfsdk:"field_configs"`
+}
+
+type fieldConfigModel struct {
+	FieldPath          types.String `tfsdk:"field_path"`
+	FieldType          types.String `tfsdk:"field_type"`
+	Retrievable        types.Bool   `tfsdk:"retrievable"`
+	Searchable         types.Bool   `tfsdk:"searchable"`
+	Indexable          types.Bool   `tfsdk:"indexable"`
+	DynamicFacetable   types.Bool   `tfsdk:"dynamic_facetable"`
+	Completable        types.Bool   `tfsdk:"completable"`
+	KeyPropertyMapping types.String `tfsdk:"key_property_mapping"`
+}
+
+var fieldConfigAttrTypes = map[string]attr.Type{
+	"field_path":           types.StringType,
+	"field_type":           types.StringType,
+	"retrievable":          types.BoolType,
+	"searchable":           types.BoolType,
+	"indexable":            types.BoolType,
+	"dynamic_facetable":    types.BoolType,
+	"completable":          types.BoolType,
+	"key_property_mapping": types.StringType,
+}
+
+func NewDataStoreSchemaDataSource(c *client.GeminiClient) datasource.DataSource {
+	return &dataStoreSchemaDataSource{
+		client: c,
+	}
+}
+
+func (d *dataStoreSchemaDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_data_store_schema"
+}
+
+func (d *dataStoreSchemaDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"data_store_id": schema.StringAttribute{
+				Required:    true,
+				Description: "ID of the data store the schema belongs to",
+			},
+			"schema_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "ID of the schema to look up. Defaults to default_schema.",
+			},
+			"name": schema.StringAttribute{
+				Computed:    true,
+				Description: "Full resource name of the schema",
+			},
+			"json_schema": schema.StringAttribute{
+				CustomType:  jsontypes.NormalizedType{},
+				Computed:    true,
+				Description: "Schema as a JSON Schema document",
+			},
+			"field_configs": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Configuration of every field in the schema, as declared by its property annotations, sorted by field path",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"field_path": schema.StringAttribute{
+							Computed:    true,
+							Description: "Dot separated path of the field",
+						},
+						"field_type": schema.StringAttribute{
+							Computed:    true,
+							Description: "JSON type of the field; array fields are reported as array<element type>",
+						},
+						"retrievable": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the field is returned in search results",
+						},
+						"searchable": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the field is searched by free text queries",
+						},
+						"indexable": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the field can be used to filter and order results",
+						},
+						"dynamic_facetable": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the field can be used as a dynamic facet",
+						},
+						"completable": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the field is used for autocomplete suggestions",
+						},
+						"key_property_mapping": schema.StringAttribute{
+							Computed:    true,
+							Description: "Key property the field is mapped to, such as title or uri",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *dataStoreSchemaDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model dataStoreSchemaDataSourceModel
+	diags := req.Config.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	schemaID := client.DefaultSchemaID
+	if !model.SchemaID.IsNull() && model.SchemaID.ValueString() != "" {
+		schemaID = model.SchemaID.ValueString()
+	}
+
+	// Build the full schema name
+	schemaName := fmt.Sprintf("projects/%s/locations/%s/collections/%s/dataStores/%s/schemas/%s",
+		d.client.Config().ProjectID,
+		d.client.Config().Location,
+		d.client.Config().Collection,
+		model.DataStoreID.ValueString(),
+		schemaID)
+
+	// Read the schema
+	dataStoreSchema, err := d.client.GetSchema(ctx, schemaName)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading data store schema",
+			clientErrorDetail(err),
+		)
+		return
+	}
+
+	fieldConfigs, err := dataStoreSchema.FieldConfigs()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error parsing data store schema",
+			fmt.Sprintf("Could not read the field configs of schema %s: %s", dataStoreSchema.Name, err),
+		)
+		return
+	}
+
+	fields := []fieldConfigModel{}
+	for _, field := range fieldConfigs {
+		fields = append(fields, fieldConfigModel{
+			FieldPath:          types.StringValue(field.FieldPath),
+			FieldType:          types.StringValue(field.FieldType),
+			Retrievable:        types.BoolValue(field.Retrievable),
+			Searchable:         types.BoolValue(field.Searchable),
+			Indexable:          types.BoolValue(field.Indexable),
+			DynamicFacetable:   types.BoolValue(field.DynamicFacetable),
+			Completable:        types.BoolValue(field.Completable),
+			KeyPropertyMapping: stringValueOrNull(field.KeyPropertyMapping),
+		})
+	}
+
+	model.Name = types.StringValue(dataStoreSchema.Name)
+	model.JSONSchema = jsontypes.NewNormalizedValue(dataStoreSchema.JSONSchema)
+	model.FieldConfigs, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: fieldConfigAttrTypes}, fields)
+	resp.Diagnostics.Append(diags...)
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
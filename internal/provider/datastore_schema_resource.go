@@ -0,0 +1,304 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	client "github.com/vb140772/terraform-provider-gemctl/internal/client"
+)
+
+// Ensure NewDataStoreSchemaResource returns a resource with the correct interface implementation
+var (
+	_ resource.Resource                = &dataStoreSchemaResource{}
+	_ resource.ResourceWithImportState = &dataStoreSchemaResource{}
+)
+
+const (
+	defaultDataStoreSchemaCreateTimeout = 20 * time.Minute
+	defaultDataStoreSchemaReadTimeout   = 5 * time.Minute
+	defaultDataStoreSchemaUpdateTimeout = 20 * time.Minute
+	defaultDataStoreSchemaDeleteTimeout = 20 * time.Minute
+)
+
+type dataStoreSchemaResource struct {
+	client *client.GeminiClient
+}
+
+type dataStoreSchemaResourceModel struct {
+	ID          types.String         `tfsdk:"id"`
+	DataStoreID types.String         `tfsdk:"data_store_id"`
+	SchemaID    types.String         `tfsdk:"schema_id"`
+	JSONSchema  jsontypes.Normalized `tfsdk:"json_schema"`
+	Name        types.String         `tfsdk:"name"`
+	Timeouts    timeouts.Value       `tfsdk:"timeouts"`
+}
+
+func NewDataStoreSchemaResource(c *client.GeminiClient) resource.Resource {
+	return &dataStoreSchemaResource{
+		client: c,
+	}
+}
+
+func (r *dataStoreSchemaResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_data_store_schema"
+}
+
+func (r *dataStoreSchemaResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the schema of a data store in Google Gemini Enterprise. Every data store has a `default_schema`, which this resource takes over and updates in place; destroying it leaves the schema on the data store.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"data_store_id": schema.StringAttribute{
+				Required:    true,
+				Description: "ID of the data store the schema belongs to. Changing this forces a new schema to be created.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"schema_id": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(client.DefaultSchemaID),
+				Description: "ID of the schema. Defaults to default_schema. Changing this forces a new schema to be created.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"json_schema": schema.StringAttribute{
+				CustomType:  jsontypes.NormalizedType{},
+				Required:    true,
+				Description: "Schema as a JSON Schema document, e.g. from jsonencode() or file(). Formatting and key order differences are ignored.",
+			},
+			"name": schema.StringAttribute{
+				Computed:    true,
+				Description: "Full resource name of the schema",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *dataStoreSchemaResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model dataStoreSchemaResourceModel
+	diags := req.Plan.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := model.Timeouts.Create(ctx, defaultDataStoreSchemaCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	// The default schema always exists, so creating it updates it in place
+	schema, err := r.client.UpdateSchema(ctx, r.schemaName(&model), model.JSONSchema.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating data store schema",
+			clientErrorDetail(err),
+		)
+		return
+	}
+
+	model.ID = types.StringValue(model.DataStoreID.ValueString() + "/" + model.SchemaID.ValueString())
+	model.Name = types.StringValue(schema.Name)
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *dataStoreSchemaResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var model dataStoreSchemaResourceModel
+	diags := req.State.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := model.Timeouts.Read(ctx, defaultDataStoreSchemaReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	// Read the schema
+	schema, err := r.client.GetSchema(ctx, r.schemaName(&model))
+	if errors.Is(err, client.ErrNotFound) {
+		// Deleted outside of Terraform, usually together with its data store
+		resp.Diagnostics.AddWarning(
+			"Data store schema no longer exists",
+			fmt.Sprintf("The schema %q of data store %q was not found and has been removed from the Terraform state. "+
+				"It was probably deleted outside of Terraform; the next apply will create it again.",
+				model.SchemaID.ValueString(), model.DataStoreID.ValueString()),
+		)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading data store schema",
+			clientErrorDetail(err),
+		)
+		return
+	}
+
+	model.ID = types.StringValue(model.DataStoreID.ValueString() + "/" + model.SchemaID.ValueString())
+	model.JSONSchema = jsontypes.NewNormalizedValue(schema.JSONSchema)
+	model.Name = types.StringValue(schema.Name)
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *dataStoreSchemaResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var model dataStoreSchemaResourceModel
+	diags := req.Plan.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := model.Timeouts.Update(ctx, defaultDataStoreSchemaUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	// Update the schema in place
+	schema, err := r.client.UpdateSchema(ctx, r.schemaName(&model), model.JSONSchema.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating data store schema",
+			clientErrorDetail(err),
+		)
+		return
+	}
+
+	model.Name = types.StringValue(schema.Name)
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *dataStoreSchemaResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var model dataStoreSchemaResourceModel
+	diags := req.State.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The default schema cannot be deleted; it goes away with its data store
+	if model.SchemaID.ValueString() == client.DefaultSchemaID {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	deleteTimeout, diags := model.Timeouts.Delete(ctx, defaultDataStoreSchemaDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	// Delete the schema
+	err := r.client.DeleteSchema(ctx, r.schemaName(&model))
+	if err != nil && !errors.Is(err, client.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Error deleting data store schema",
+			clientErrorDetail(err),
+		)
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *dataStoreSchemaResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	dataStoreID, schemaID, err := schemaIDsFromImportID(r.client.Config(), req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid data store schema import ID",
+			err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), dataStoreID+"/"+schemaID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("data_store_id"), dataStoreID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("schema_id"), schemaID)...)
+}
+
+// schemaName builds the full resource name of the schema
+func (r *dataStoreSchemaResource) schemaName(model *dataStoreSchemaResourceModel) string {
+	return fmt.Sprintf("projects/%s/locations/%s/collections/%s/dataStores/%s/schemas/%s",
+		r.client.Config().ProjectID,
+		r.client.Config().Location,
+		r.client.Config().Collection,
+		model.DataStoreID.ValueString(),
+		model.SchemaID.ValueString())
+}
+
+// schemaIDsFromImportID splits a schema import ID of the form data_store_id,
+// data_store_id/schema_id or a full schema resource name into its data store
+// and schema IDs
+func schemaIDsFromImportID(config *client.Config, importID string) (string, string, error) {
+	dataStorePart, schemaID := importID, client.DefaultSchemaID
+	if i := strings.LastIndex(importID, "/schemas/"); i >= 0 {
+		dataStorePart, schemaID = importID[:i], importID[i+len("/schemas/"):]
+	} else if parts := strings.Split(importID, "/"); len(parts) == 2 {
+		dataStorePart, schemaID = parts[0], parts[1]
+	}
+
+	if schemaID == "" || strings.Contains(schemaID, "/") {
+		return "", "", fmt.Errorf("expected data_store_id, data_store_id/schema_id or a full schema name, got %q", importID)
+	}
+
+	dataStoreID, err := shortIDFromImportID(config, dataStorePart, "dataStores")
+	if err != nil {
+		return "", "", err
+	}
+
+	return dataStoreID, schemaID, nil
+}
@@ -0,0 +1,42 @@
+package provider
+
+import (
+	"errors"
+
+	client "github.com/vb140772/terraform-provider-gemctl/internal/client"
+)
+
+// clientErrorDetail renders a client error as diagnostic detail, appending a hint
+// on how to resolve the most common classes of API failures
+func clientErrorDetail(err error) string {
+	detail := err.Error()
+
+	var timeoutErr *client.OperationTimeoutError
+	switch {
+	case errors.As(err, &timeoutErr):
+		detail += "\n\nThe operation " + timeoutErr.Operation + " did not finish before the configured timeout. " +
+			"Increase the matching value in the resource's timeouts block, wait for the operation to complete " +
+			"and run terraform apply again."
+	case errors.Is(err, client.ErrNotFound):
+		detail += "\n\nThe requested resource does not exist. Check the project_id, location and collection " +
+			"configured on the provider, and whether the resource was deleted outside of Terraform."
+	case errors.Is(err, client.ErrAlreadyExists):
+		detail += "\n\nA resource with this ID already exists. Bring it under management with terraform import " +
+			"or an import block, or choose a different ID."
+	case errors.Is(err, client.ErrPermissionDenied):
+		detail += "\n\nThe caller lacks permission for this request. Make sure the Discovery Engine API is enabled " +
+			"in the project and the credentials used by the provider hold a role such as " +
+			"roles/discoveryengine.admin."
+	case errors.Is(err, client.ErrFailedPrecondition):
+		detail += "\n\nThe resource is not in a state that allows this request, for example a data store that is " +
+			"still attached to an engine or has an operation in progress. Resolve the conflict and retry."
+	case errors.Is(err, client.ErrInvalidArgument):
+		detail += "\n\nThe API rejected a value of the request. Check the resource's arguments against the " +
+			"values the API accepts for this kind of resource."
+	case errors.Is(err, client.ErrResourceExhausted):
+		detail += "\n\nA Discovery Engine quota or rate limit was exceeded. Retry later or request a quota " +
+			"increase for the project."
+	}
+
+	return detail
+}
@@ -0,0 +1,285 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+
+	client "github.com/vb140772/terraform-provider-gemctl/internal/client"
+)
+
+// defaultChunkSize is the chunk size the API applies when none is configured
+const defaultChunkSize = 500
+
+type documentProcessingConfigModel struct {
+	DefaultParsingConfig  types.Object `tfsdk:"default_parsing_config"`
+	ParsingConfigOverride types.Set    `tfsdk:"parsing_config_override"`
+	ChunkingConfig        types.Object `tfsdk:"chunking_config"`
+}
+
+type parsingConfigModel struct {
+	Parser        types.String `tfsdk:"parser"`
+	UseNativeText types.Bool   `tfsdk:"use_native_text"`
+}
+
+type parsingConfigOverrideModel struct {
+	FileType      types.String `tfsdk:"file_type"`
+	Parser        types.String `tfsdk:"parser"`
+	UseNativeText types.Bool   `tfsdk:"use_native_text"`
+}
+
+type chunkingConfigModel struct {
+	ChunkSize               types.Int64 `tfsdk:"chunk_size"`
+	IncludeAncestorHeadings types.Bool  `tfsdk:"include_ancestor_headings"`
+}
+
+var (
+	parsingConfigAttrTypes = map[string]attr.Type{
+		"parser":          types.StringType,
+		"use_native_text": types.BoolType,
+	}
+
+	parsingConfigOverrideAttrTypes = map[string]attr.Type{
+		"file_type":       types.StringType,
+		"parser":          types.StringType,
+		"use_native_text": types.BoolType,
+	}
+
+	chunkingConfigAttrTypes = map[string]attr.Type{
+		"chunk_size":                types.Int64Type,
+		"include_ancestor_headings": types.BoolType,
+	}
+
+	documentProcessingConfigAttrTypes = map[string]attr.Type{
+		"default_parsing_config":  types.ObjectType{AttrTypes: parsingConfigAttrTypes},
+		"parsing_config_override": types.SetType{ElemType: types.ObjectType{AttrTypes: parsingConfigOverrideAttrTypes}},
+		"chunking_config":         types.ObjectType{AttrTypes: chunkingConfigAttrTypes},
+	}
+)
+
+// parsingConfigAttributes returns the attributes selecting a document parser
+func parsingConfigAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"parser": schema.StringAttribute{
+			Required:    true,
+			Description: "Parser to use: digital, ocr (PDF only) or layout",
+			Validators: []validator.String{
+				stringvalidator.OneOf(client.ParserDigital, client.ParserOCR, client.ParserLayout),
+			},
+		},
+		"use_native_text": schema.BoolAttribute{
+			Optional:    true,
+			Description: "Use native text instead of OCR text on pages that contain native text. Only applies to the ocr parser.",
+		},
+	}
+}
+
+// documentProcessingConfigBlock returns the schema of the document_processing_config block
+func documentProcessingConfigBlock() schema.SingleNestedBlock {
+	overrideAttributes := parsingConfigAttributes()
+	overrideAttributes["file_type"] = schema.StringAttribute{
+		Required:    true,
+		Description: "File type the parser applies to: pdf, html, docx, pptx, xlsm or xlsx. Only pdf supports the ocr parser.",
+		Validators: []validator.String{
+			stringvalidator.OneOf("pdf", "html", "docx", "pptx", "xlsm", "xlsx"),
+		},
+	}
+
+	return schema.SingleNestedBlock{
+		Description: "How documents are parsed and chunked. The API fixes this when the data store is created, so changing it forces a new data store to be created. " +
+			"Not populated by terraform import; on a data store adopted with terraform import the first apply records the configured block without replacing the data store.",
+		PlanModifiers: []planmodifier.Object{
+			objectplanmodifier.RequiresReplaceIf(
+				documentProcessingConfigChanged,
+				"Changing the document processing config forces a new data store to be created.",
+				"Changing the document processing config forces a new data store to be created.",
+			),
+		},
+		Blocks: map[string]schema.Block{
+			"default_parsing_config": schema.SingleNestedBlock{
+				Description: "Parser applied to every file type without an override. Defaults to the digital parser.",
+				Attributes:  parsingConfigAttributes(),
+			},
+			"parsing_config_override": schema.SetNestedBlock{
+				Description: "Parser applied to a specific file type instead of the default",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: overrideAttributes,
+				},
+			},
+			"chunking_config": schema.SingleNestedBlock{
+				Description: "Layout based chunking of parsed documents. Requires the layout parser.",
+				Attributes: map[string]schema.Attribute{
+					"chunk_size": schema.Int64Attribute{
+						Optional:    true,
+						Description: "Token limit of each chunk, between 100 and 500. Defaults to 500.",
+						Validators: []validator.Int64{
+							int64validator.Between(100, 500),
+						},
+					},
+					"include_ancestor_headings": schema.BoolAttribute{
+						Optional:    true,
+						Description: "Prepend the headings of enclosing sections to chunks from the middle of a document to keep their context",
+					},
+				},
+			},
+		},
+	}
+}
+
+// documentProcessingConfigFromObject converts the document_processing_config
+// block to its client form, returning nil when the block is not set
+func documentProcessingConfigFromObject(ctx context.Context, obj types.Object) (*client.DocumentProcessingConfig, diag.Diagnostics) {
+	if obj.IsNull() || obj.IsUnknown() {
+		return nil, nil
+	}
+
+	var config documentProcessingConfigModel
+	diags := obj.As(ctx, &config, basetypes.ObjectAsOptions{})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	result := &client.DocumentProcessingConfig{}
+
+	if !config.DefaultParsingConfig.IsNull() && !config.DefaultParsingConfig.IsUnknown() {
+		var parsing parsingConfigModel
+		diags.Append(config.DefaultParsingConfig.As(ctx, &parsing, basetypes.ObjectAsOptions{})...)
+		result.DefaultParsingConfig = &client.ParsingConfig{
+			Parser:        parsing.Parser.ValueString(),
+			UseNativeText: parsing.UseNativeText.ValueBool(),
+		}
+	}
+
+	if !config.ParsingConfigOverride.IsNull() && !config.ParsingConfigOverride.IsUnknown() {
+		var overrides []parsingConfigOverrideModel
+		diags.Append(config.ParsingConfigOverride.ElementsAs(ctx, &overrides, false)...)
+		if len(overrides) > 0 {
+			result.ParsingConfigOverrides = make(map[string]client.ParsingConfig)
+		}
+		for _, override := range overrides {
+			result.ParsingConfigOverrides[override.FileType.ValueString()] = client.ParsingConfig{
+				Parser:        override.Parser.ValueString(),
+				UseNativeText: override.UseNativeText.ValueBool(),
+			}
+		}
+	}
+
+	if !config.ChunkingConfig.IsNull() && !config.ChunkingConfig.IsUnknown() {
+		var chunking chunkingConfigModel
+		diags.Append(config.ChunkingConfig.As(ctx, &chunking, basetypes.ObjectAsOptions{})...)
+		result.ChunkingConfig = &client.ChunkingConfig{
+			ChunkSize:               chunking.ChunkSize.ValueInt64(),
+			IncludeAncestorHeadings: chunking.IncludeAncestorHeadings.ValueBool(),
+		}
+	}
+
+	return result, diags
+}
+
+// documentProcessingConfigChanged requires replacement for any change to the
+// block, except when it is first recorded on a data store adopted with
+// terraform import, whose config cannot be read back reliably
+func documentProcessingConfigChanged(ctx context.Context, req planmodifier.ObjectRequest, resp *objectplanmodifier.RequiresReplaceIfFuncResponse) {
+	if req.StateValue.IsNull() {
+		adopted, diags := privateFlag(ctx, req.Private, dataStoreAdoptedKey)
+		resp.Diagnostics.Append(diags...)
+		if adopted {
+			return
+		}
+	}
+
+	resp.RequiresReplace = true
+}
+
+// refreshDocumentProcessingConfig updates the document_processing_config block
+// from the data store. The API fills in defaults for anything left unset, so
+// only blocks present in state are refreshed and unset optional values stay
+// null while the API reports their default.
+func refreshDocumentProcessingConfig(ctx context.Context, current types.Object, remote *client.DocumentProcessingConfig) (types.Object, diag.Diagnostics) {
+	if current.IsNull() || current.IsUnknown() || remote == nil {
+		return current, nil
+	}
+
+	var config documentProcessingConfigModel
+	diags := current.As(ctx, &config, basetypes.ObjectAsOptions{})
+	if diags.HasError() {
+		return current, diags
+	}
+
+	if !config.DefaultParsingConfig.IsNull() && remote.DefaultParsingConfig != nil {
+		var parsing parsingConfigModel
+		diags.Append(config.DefaultParsingConfig.As(ctx, &parsing, basetypes.ObjectAsOptions{})...)
+		parsing.Parser = types.StringValue(remote.DefaultParsingConfig.Parser)
+		parsing.UseNativeText = refreshOptionalBool(parsing.UseNativeText, remote.DefaultParsingConfig.UseNativeText)
+
+		var objDiags diag.Diagnostics
+		config.DefaultParsingConfig, objDiags = types.ObjectValueFrom(ctx, parsingConfigAttrTypes, parsing)
+		diags.Append(objDiags...)
+	}
+
+	if len(remote.ParsingConfigOverrides) > 0 || len(config.ParsingConfigOverride.Elements()) > 0 {
+		var currentOverrides []parsingConfigOverrideModel
+		diags.Append(config.ParsingConfigOverride.ElementsAs(ctx, &currentOverrides, false)...)
+		currentByFileType := make(map[string]parsingConfigOverrideModel)
+		for _, override := range currentOverrides {
+			currentByFileType[override.FileType.ValueString()] = override
+		}
+
+		overrides := []parsingConfigOverrideModel{}
+		for fileType, parsing := range remote.ParsingConfigOverrides {
+			override := parsingConfigOverrideModel{
+				FileType: types.StringValue(fileType),
+				Parser:   types.StringValue(parsing.Parser),
+			}
+			override.UseNativeText = refreshOptionalBool(currentByFileType[fileType].UseNativeText, parsing.UseNativeText)
+			overrides = append(overrides, override)
+		}
+
+		var setDiags diag.Diagnostics
+		config.ParsingConfigOverride, setDiags = types.SetValueFrom(ctx, types.ObjectType{AttrTypes: parsingConfigOverrideAttrTypes}, overrides)
+		diags.Append(setDiags...)
+	}
+
+	if !config.ChunkingConfig.IsNull() && remote.ChunkingConfig != nil {
+		var chunking chunkingConfigModel
+		diags.Append(config.ChunkingConfig.As(ctx, &chunking, basetypes.ObjectAsOptions{})...)
+		chunkSize := remote.ChunkingConfig.ChunkSize
+		if chunkSize == 0 {
+			chunkSize = defaultChunkSize
+		}
+		if !chunking.ChunkSize.IsNull() || chunkSize != defaultChunkSize {
+			chunking.ChunkSize = types.Int64Value(chunkSize)
+		}
+		chunking.IncludeAncestorHeadings = refreshOptionalBool(chunking.IncludeAncestorHeadings, remote.ChunkingConfig.IncludeAncestorHeadings)
+
+		var objDiags diag.Diagnostics
+		config.ChunkingConfig, objDiags = types.ObjectValueFrom(ctx, chunkingConfigAttrTypes, chunking)
+		diags.Append(objDiags...)
+	}
+
+	if diags.HasError() {
+		return current, diags
+	}
+
+	obj, objDiags := types.ObjectValueFrom(ctx, documentProcessingConfigAttrTypes, config)
+	diags.Append(objDiags...)
+	return obj, diags
+}
+
+// refreshOptionalBool returns the remote value of an optional boolean, keeping
+// an unset value null while the remote value is false
+func refreshOptionalBool(current types.Bool, remote bool) types.Bool {
+	if current.IsNull() && !remote {
+		return current
+	}
+	return types.BoolValue(remote)
+}
@@ -0,0 +1,599 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+
+	client "github.com/vb140772/terraform-provider-gemctl/internal/client"
+)
+
+// Ensure NewDocumentResource returns a resource with the correct interface implementation
+var (
+	_ resource.Resource                = &documentResource{}
+	_ resource.ResourceWithImportState = &documentResource{}
+	_ resource.ResourceWithModifyPlan  = &documentResource{}
+)
+
+const (
+	defaultDocumentCreateTimeout = 5 * time.Minute
+	defaultDocumentReadTimeout   = 5 * time.Minute
+	defaultDocumentUpdateTimeout = 5 * time.Minute
+	defaultDocumentDeleteTimeout = 5 * time.Minute
+)
+
+// documentIDPattern matches the document IDs accepted by the API
+var documentIDPattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]*$`)
+
+type documentResource struct {
+	client *client.GeminiClient
+}
+
+type documentResourceModel struct {
+	ID               types.String         `tfsdk:"id"`
+	DataStoreID      types.String         `tfsdk:"data_store_id"`
+	Branch           types.String         `tfsdk:"branch"`
+	DocumentID       types.String         `tfsdk:"document_id"`
+	SchemaID         types.String         `tfsdk:"schema_id"`
+	ParentDocumentID types.String         `tfsdk:"parent_document_id"`
+	StructData       jsontypes.Normalized `tfsdk:"struct_data"`
+	JSONData         jsontypes.Normalized `tfsdk:"json_data"`
+	Content          types.Object         `tfsdk:"content"`
+	ContentSHA256    types.String         `tfsdk:"content_sha256"`
+	Name             types.String         `tfsdk:"name"`
+	Timeouts         timeouts.Value       `tfsdk:"timeouts"`
+}
+
+type documentContentModel struct {
+	MimeType types.String `tfsdk:"mime_type"`
+	File     types.String `tfsdk:"file"`
+	URI      types.String `tfsdk:"uri"`
+}
+
+var documentContentAttrTypes = map[string]attr.Type{
+	"mime_type": types.StringType,
+	"file":      types.StringType,
+	"uri":       types.StringType,
+}
+
+func NewDocumentResource(c *client.GeminiClient) resource.Resource {
+	return &documentResource{
+		client: c,
+	}
+}
+
+func (r *documentResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_document"
+}
+
+func (r *documentResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a single document on a data store branch in Google Gemini Enterprise. Documents carry structured data, unstructured content read from a local file or GCS, or both.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"data_store_id": schema.StringAttribute{
+				Required:    true,
+				Description: "ID of the data store the document belongs to. Changing this forces a new document to be created.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"branch": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(client.DefaultBranch),
+				Description: "Branch of the data store the document is written to. Defaults to default_branch. Changing this forces a new document to be created.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"document_id": schema.StringAttribute{
+				Required:    true,
+				Description: "Unique identifier for the document within the branch. Changing this forces a new document to be created.",
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 128),
+					stringvalidator.RegexMatches(documentIDPattern, "must start with a letter or digit and contain only letters, digits, underscores and hyphens"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"schema_id": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(client.DefaultSchemaID),
+				Description: "ID of the data store schema the structured data conforms to. Defaults to default_schema.",
+			},
+			"parent_document_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "ID of the parent document, for data stores with hierarchical documents",
+			},
+			"struct_data": schema.StringAttribute{
+				CustomType:  jsontypes.NormalizedType{},
+				Optional:    true,
+				Description: "Structured data of the document as a JSON object, e.g. from jsonencode(). Formatting and key order differences are ignored.",
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("json_data")),
+				},
+			},
+			"json_data": schema.StringAttribute{
+				CustomType:  jsontypes.NormalizedType{},
+				Optional:    true,
+				Description: "Structured data of the document as a JSON string, e.g. from file(). Formatting and key order differences are ignored.",
+			},
+			"content_sha256": schema.StringAttribute{
+				Computed:    true,
+				Description: "SHA-256 hash of the local file in content.file. A change to the file changes the hash and updates the document.",
+			},
+			"name": schema.StringAttribute{
+				Computed:    true,
+				Description: "Full resource name of the document",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"content": schema.SingleNestedBlock{
+				Description: "Unstructured content of the document, uploaded from a local file or referenced in GCS. Exactly one of file and uri must be set.",
+				Attributes: map[string]schema.Attribute{
+					"mime_type": schema.StringAttribute{
+						Required:    true,
+						Description: "MIME type of the content, e.g. application/pdf, text/html, text/plain or application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+					},
+					"file": schema.StringAttribute{
+						Optional:    true,
+						Description: "Path of a local file whose bytes are uploaded as the content",
+						Validators: []validator.String{
+							stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("uri")),
+						},
+					},
+					"uri": schema.StringAttribute{
+						Optional:    true,
+						Description: "Cloud Storage URI of the content (e.g., gs://bucket/path/file.pdf)",
+						Validators: []validator.String{
+							stringvalidator.RegexMatches(gcsURIPattern, "must be a Cloud Storage URI of the form gs://bucket/path"),
+						},
+					},
+				},
+			},
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// ModifyPlan hashes the local content file, so edits to the file show up as a
+// change to content_sha256 and update the document
+func (r *documentResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Destroys have nothing to plan
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan documentResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	contentSHA256 := types.StringNull()
+	if !plan.Content.IsNull() {
+		if plan.Content.IsUnknown() {
+			contentSHA256 = types.StringUnknown()
+		} else {
+			var content documentContentModel
+			resp.Diagnostics.Append(plan.Content.As(ctx, &content, basetypes.ObjectAsOptions{})...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+
+			switch {
+			case content.File.IsUnknown() || content.URI.IsUnknown():
+				contentSHA256 = types.StringUnknown()
+			case content.File.IsNull() && content.URI.IsNull():
+				resp.Diagnostics.AddAttributeError(
+					path.Root("content"),
+					"Missing document content source",
+					"The content block requires either file or uri to be set.",
+				)
+				return
+			case !content.File.IsNull():
+				hash, _, err := readFileSHA256(content.File.ValueString())
+				if err != nil {
+					resp.Diagnostics.AddAttributeError(
+						path.Root("content").AtName("file"),
+						"Error reading document content",
+						err.Error(),
+					)
+					return
+				}
+				contentSHA256 = types.StringValue(hash)
+			}
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("content_sha256"), contentSHA256)...)
+}
+
+func (r *documentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model documentResourceModel
+	diags := req.Plan.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := model.Timeouts.Create(ctx, defaultDocumentCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	input, diags := model.documentInput(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Build the full branch name
+	branchName := fmt.Sprintf("projects/%s/locations/%s/collections/%s/dataStores/%s/branches/%s",
+		r.client.Config().ProjectID,
+		r.client.Config().Location,
+		r.client.Config().Collection,
+		model.DataStoreID.ValueString(),
+		model.Branch.ValueString())
+
+	// Create the document
+	document, err := r.client.CreateDocument(ctx, branchName, model.DocumentID.ValueString(), input)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating document",
+			clientErrorDetail(err),
+		)
+		return
+	}
+
+	model.ID = types.StringValue(model.importID())
+	model.Name = types.StringValue(document.Name)
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *documentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var model documentResourceModel
+	diags := req.State.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := model.Timeouts.Read(ctx, defaultDocumentReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	// Read the document
+	document, err := r.client.GetDocument(ctx, r.documentName(&model))
+	if errors.Is(err, client.ErrNotFound) {
+		// Deleted outside of Terraform; dropping it from state plans a recreate
+		resp.Diagnostics.AddWarning(
+			"Document no longer exists",
+			fmt.Sprintf("The document %q in data store %q was not found and has been removed from the Terraform state. "+
+				"It was probably deleted outside of Terraform; the next apply will create it again.",
+				model.DocumentID.ValueString(), model.DataStoreID.ValueString()),
+		)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading document",
+			clientErrorDetail(err),
+		)
+		return
+	}
+
+	model.ID = types.StringValue(model.importID())
+	model.Name = types.StringValue(document.Name)
+	if document.SchemaID != "" {
+		model.SchemaID = types.StringValue(document.SchemaID)
+	}
+	if !model.ParentDocumentID.IsNull() || document.ParentDocumentID != "" {
+		model.ParentDocumentID = stringValueOrNull(document.ParentDocumentID)
+	}
+
+	// The API may return data written as json_data in structData, so the
+	// structured data is refreshed into whichever attribute holds it
+	data := document.JSONData
+	if data == "" {
+		data = document.StructData
+	}
+	if !model.JSONData.IsNull() {
+		model.JSONData = normalizedValueOrNull(data)
+	} else if !model.StructData.IsNull() || data != "" {
+		model.StructData = normalizedValueOrNull(data)
+	}
+
+	model.Content, diags = refreshDocumentContent(ctx, model.Content, document)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *documentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var model documentResourceModel
+	diags := req.Plan.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := model.Timeouts.Update(ctx, defaultDocumentUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	input, diags := model.documentInput(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Replace the document's data in place
+	document, err := r.client.UpdateDocument(ctx, r.documentName(&model), input)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating document",
+			clientErrorDetail(err),
+		)
+		return
+	}
+
+	model.Name = types.StringValue(document.Name)
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *documentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var model documentResourceModel
+	diags := req.State.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := model.Timeouts.Delete(ctx, defaultDocumentDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	// Delete the document
+	err := r.client.DeleteDocument(ctx, r.documentName(&model))
+	if err != nil && !errors.Is(err, client.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Error deleting document",
+			clientErrorDetail(err),
+		)
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *documentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	dataStoreID, branch, documentID, err := documentIDsFromImportID(r.client.Config(), req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid document import ID",
+			err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), dataStoreID+"/"+branch+"/"+documentID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("data_store_id"), dataStoreID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("branch"), branch)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("document_id"), documentID)...)
+}
+
+// documentName builds the full resource name of the document
+func (r *documentResource) documentName(model *documentResourceModel) string {
+	return fmt.Sprintf("projects/%s/locations/%s/collections/%s/dataStores/%s/branches/%s/documents/%s",
+		r.client.Config().ProjectID,
+		r.client.Config().Location,
+		r.client.Config().Collection,
+		model.DataStoreID.ValueString(),
+		model.Branch.ValueString(),
+		model.DocumentID.ValueString())
+}
+
+// importID returns the ID of the document in the form accepted by terraform import
+func (m *documentResourceModel) importID() string {
+	return m.DataStoreID.ValueString() + "/" + m.Branch.ValueString() + "/" + m.DocumentID.ValueString()
+}
+
+// documentInput builds the data written to the document, reading the local
+// content file if one is configured. The file must still match the hash
+// recorded in the plan.
+func (m *documentResourceModel) documentInput(ctx context.Context) (*client.DocumentInput, diag.Diagnostics) {
+	input := &client.DocumentInput{
+		SchemaID:         m.SchemaID.ValueString(),
+		ParentDocumentID: m.ParentDocumentID.ValueString(),
+		StructData:       m.StructData.ValueString(),
+		JSONData:         m.JSONData.ValueString(),
+	}
+
+	if m.Content.IsNull() || m.Content.IsUnknown() {
+		return input, nil
+	}
+
+	var content documentContentModel
+	diags := m.Content.As(ctx, &content, basetypes.ObjectAsOptions{})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	input.Content = &client.DocumentContent{
+		MimeType: content.MimeType.ValueString(),
+		URI:      content.URI.ValueString(),
+	}
+
+	if !content.File.IsNull() {
+		hash, data, err := readFileSHA256(content.File.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("content").AtName("file"), "Error reading document content", err.Error())
+			return nil, diags
+		}
+		if !m.ContentSHA256.IsUnknown() && hash != m.ContentSHA256.ValueString() {
+			diags.AddAttributeError(
+				path.Root("content").AtName("file"),
+				"Document content changed during apply",
+				fmt.Sprintf("The file %s changed after the plan was made. Run terraform apply again to upload the new content.", content.File.ValueString()),
+			)
+			return nil, diags
+		}
+		input.Content.RawBytes = data
+		m.ContentSHA256 = types.StringValue(hash)
+	}
+
+	return input, diags
+}
+
+// refreshDocumentContent updates the content block from the document. Content
+// uploaded from a local file cannot be read back, so only the MIME type and
+// GCS URI are refreshed, and the block is only populated on import when the
+// content lives in GCS.
+func refreshDocumentContent(ctx context.Context, current types.Object, document *client.Document) (types.Object, diag.Diagnostics) {
+	mimeType, _ := document.Content["mimeType"].(string)
+	uri, _ := document.Content["uri"].(string)
+
+	var content documentContentModel
+	if current.IsNull() || current.IsUnknown() {
+		if uri == "" {
+			return current, nil
+		}
+		content = documentContentModel{
+			File: types.StringNull(),
+			URI:  types.StringValue(uri),
+		}
+	} else {
+		diags := current.As(ctx, &content, basetypes.ObjectAsOptions{})
+		if diags.HasError() {
+			return current, diags
+		}
+		if !content.URI.IsNull() && uri != "" {
+			content.URI = types.StringValue(uri)
+		}
+	}
+
+	if mimeType != "" {
+		content.MimeType = types.StringValue(mimeType)
+	}
+
+	return types.ObjectValueFrom(ctx, documentContentAttrTypes, content)
+}
+
+// documentIDsFromImportID splits a document import ID of the form
+// data_store_id/document_id, data_store_id/branch/document_id or a full
+// document resource name into its data store, branch and document IDs
+func documentIDsFromImportID(config *client.Config, importID string) (string, string, string, error) {
+	var dataStorePart, branch, documentID string
+	if i := strings.LastIndex(importID, "/branches/"); i >= 0 {
+		dataStorePart = importID[:i]
+		parts := strings.Split(importID[i+len("/branches/"):], "/")
+		if len(parts) == 3 && parts[1] == "documents" {
+			branch, documentID = parts[0], parts[2]
+		}
+	} else {
+		parts := strings.Split(importID, "/")
+		switch len(parts) {
+		case 2:
+			dataStorePart, branch, documentID = parts[0], client.DefaultBranch, parts[1]
+		case 3:
+			dataStorePart, branch, documentID = parts[0], parts[1], parts[2]
+		}
+	}
+
+	if dataStorePart == "" || branch == "" || documentID == "" {
+		return "", "", "", fmt.Errorf("expected data_store_id/document_id, data_store_id/branch/document_id or a full document name, got %q", importID)
+	}
+
+	dataStoreID, err := shortIDFromImportID(config, dataStorePart, "dataStores")
+	if err != nil {
+		return "", "", "", err
+	}
+
+	return dataStoreID, branch, documentID, nil
+}
+
+// readFileSHA256 reads a local file and returns its hex encoded SHA-256 hash
+// along with its contents
+func readFileSHA256(name string) (string, []byte, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return "", nil, err
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), data, nil
+}
+
+// normalizedValueOrNull returns a normalized JSON value, or null when s is empty
+func normalizedValueOrNull(s string) jsontypes.Normalized {
+	if s == "" {
+		return jsontypes.NewNormalizedNull()
+	}
+	return jsontypes.NewNormalizedValue(s)
+}
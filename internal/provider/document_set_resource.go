@@ -0,0 +1,676 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	client "github.com/vb140772/terraform-provider-gemctl/internal/client"
+)
+
+// Ensure NewDocumentSetResource returns a resource with the correct interface implementation
+var (
+	_ resource.Resource               = &documentSetResource{}
+	_ resource.ResourceWithModifyPlan = &documentSetResource{}
+)
+
+const (
+	defaultDocumentSetCreateTimeout = 30 * time.Minute
+	defaultDocumentSetReadTimeout   = 5 * time.Minute
+	defaultDocumentSetUpdateTimeout = 30 * time.Minute
+	defaultDocumentSetDeleteTimeout = 30 * time.Minute
+
+	// defaultDocumentSetParallelism is the number of documents written at once
+	defaultDocumentSetParallelism = 4
+)
+
+type documentSetResource struct {
+	client *client.GeminiClient
+}
+
+type documentSetResourceModel struct {
+	ID               types.String   `tfsdk:"id"`
+	DataStoreID      types.String   `tfsdk:"data_store_id"`
+	Branch           types.String   `tfsdk:"branch"`
+	Directory        types.String   `tfsdk:"directory"`
+	Patterns         types.List     `tfsdk:"patterns"`
+	MimeTypes        types.Map      `tfsdk:"mime_types"`
+	Parallelism      types.Int64    `tfsdk:"parallelism"`
+	Documents        types.Map      `tfsdk:"documents"`
+	DocumentsAdded   types.Int64    `tfsdk:"documents_added"`
+	DocumentsChanged types.Int64    `tfsdk:"documents_changed"`
+	DocumentsRemoved types.Int64    `tfsdk:"documents_removed"`
+	Timeouts         timeouts.Value `tfsdk:"timeouts"`
+}
+
+func NewDocumentSetResource(c *client.GeminiClient) resource.Resource {
+	return &documentSetResource{
+		client: c,
+	}
+}
+
+func (r *documentSetResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_document_set"
+}
+
+func (r *documentSetResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Syncs the files of a local directory into a data store branch in Google Gemini Enterprise. " +
+			"Every matching file becomes a document whose ID is derived from its relative path: new files are created, edited files are updated and documents whose file was removed are deleted. " +
+			"Only the documents written by the set are managed; other documents on the branch, such as imported ones, are left alone. A document that already exists with the ID of a file is overwritten by it.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"data_store_id": schema.StringAttribute{
+				Required:    true,
+				Description: "ID of the data store to sync the documents into. Changing this forces a new document set to be created.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"branch": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(client.DefaultBranch),
+				Description: "Branch of the data store the documents are synced into. Defaults to default_branch. Changing this forces a new document set to be created.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"directory": schema.StringAttribute{
+				Required:    true,
+				Description: "Local directory to sync, e.g. \"${path.module}/policies\"",
+			},
+			"patterns": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+				Default:     listdefault.StaticValue(types.ListValueMust(types.StringType, []attr.Value{types.StringValue("**")})),
+				Description: "Glob patterns selecting the files to sync, relative to directory. ** matches any number of directories. Defaults to every file.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"mime_types": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "MIME types by file extension (e.g. { \".markdown\" = \"text/plain\" }), overriding the built-in mapping for pdf, html, txt, md, docx, pptx, xlsx and xlsm files",
+			},
+			"parallelism": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(defaultDocumentSetParallelism),
+				Description: "Number of documents written or deleted at the same time, between 1 and 32. Defaults to 4.",
+				Validators: []validator.Int64{
+					int64validator.Between(1, 32),
+				},
+			},
+			"documents": schema.MapAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "SHA-256 hash of the file behind each document written by this resource, by document ID",
+			},
+			"documents_added": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Number of documents created by the last sync",
+			},
+			"documents_changed": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Number of documents updated by the last sync",
+			},
+			"documents_removed": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Number of documents deleted by the last sync",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// ModifyPlan scans the directory and plans the documents to sync, reporting
+// how many are added, changed and removed
+func (r *documentSetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Destroys have nothing to plan
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan documentSetResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Directory.IsUnknown() || plan.Patterns.IsUnknown() || plan.MimeTypes.IsUnknown() {
+		return
+	}
+
+	local, diags := plan.localDocuments(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current := map[string]string{}
+	var state documentSetResourceModel
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		current, diags = state.documentHashes(ctx)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	added, changed, removed := diffDocuments(local, current)
+	if len(added)+len(changed)+len(removed) == 0 && !req.State.Raw.IsNull() {
+		// Nothing to sync: keep the counts of the last sync
+		plan.DocumentsAdded = state.DocumentsAdded
+		plan.DocumentsChanged = state.DocumentsChanged
+		plan.DocumentsRemoved = state.DocumentsRemoved
+	} else {
+		plan.DocumentsAdded = types.Int64Value(int64(len(added)))
+		plan.DocumentsChanged = types.Int64Value(int64(len(changed)))
+		plan.DocumentsRemoved = types.Int64Value(int64(len(removed)))
+	}
+
+	// A new set may write only some of its documents, so its hashes are only
+	// known after apply
+	if req.State.Raw.IsNull() {
+		plan.Documents = types.MapUnknown(types.StringType)
+	} else {
+		hashes := make(map[string]string, len(local))
+		for id, doc := range local {
+			hashes[id] = doc.SHA256
+		}
+		plan.Documents, diags = types.MapValueFrom(ctx, types.StringType, hashes)
+		resp.Diagnostics.Append(diags...)
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("documents"), plan.Documents)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("documents_added"), plan.DocumentsAdded)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("documents_changed"), plan.DocumentsChanged)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("documents_removed"), plan.DocumentsRemoved)...)
+}
+
+func (r *documentSetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model documentSetResourceModel
+	diags := req.Plan.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := model.Timeouts.Create(ctx, defaultDocumentSetCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	model.ID = types.StringValue(model.DataStoreID.ValueString() + "/" + model.Branch.ValueString())
+
+	// A new set manages no documents yet, so nothing on the branch is deleted.
+	// An error would taint the set and rewrite every document on the next
+	// apply; documents that failed are left out of state instead, so the next
+	// apply only retries them.
+	failedWrites, _, diags := r.sync(ctx, &model, map[string]string{})
+	resp.Diagnostics.Append(diags...)
+	if len(failedWrites) > 0 {
+		resp.Diagnostics.AddWarning(
+			"Some documents could not be written",
+			documentSetErrorDetail("write", failedWrites),
+		)
+	}
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *documentSetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var model documentSetResourceModel
+	diags := req.State.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := model.Timeouts.Read(ctx, defaultDocumentSetReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	current, diags := model.documentHashes(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.GetDataStoreDetails(ctx, r.dataStoreName(&model))
+	if errors.Is(err, client.ErrNotFound) {
+		// The data store was deleted outside of Terraform
+		resp.Diagnostics.AddWarning(
+			"Document set data store no longer exists",
+			fmt.Sprintf("The data store %q was not found and the document set has been removed from the Terraform state. "+
+				"It was probably deleted outside of Terraform; the next apply will sync the documents again.",
+				model.DataStoreID.ValueString()),
+		)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading data store",
+			clientErrorDetail(err),
+		)
+		return
+	}
+
+	// Documents of the set deleted outside of Terraform are dropped from state
+	// so the next sync creates them again
+	existing, err := r.existingDocuments(ctx, &model, current)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading documents",
+			clientErrorDetail(err),
+		)
+		return
+	}
+
+	model.Documents, diags = types.MapValueFrom(ctx, types.StringType, existing)
+	resp.Diagnostics.Append(diags...)
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *documentSetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var model, state documentSetResourceModel
+	diags := req.Plan.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := model.Timeouts.Update(ctx, defaultDocumentSetUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	current, diags := state.documentHashes(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	failedWrites, failedDeletes, diags := r.sync(ctx, &model, current)
+	resp.Diagnostics.Append(diags...)
+	if len(failedWrites) > 0 {
+		resp.Diagnostics.AddError(
+			"Error writing documents",
+			documentSetErrorDetail("write", failedWrites),
+		)
+	}
+	if len(failedDeletes) > 0 {
+		resp.Diagnostics.AddError(
+			"Error deleting documents",
+			documentSetErrorDetail("delete", failedDeletes),
+		)
+	}
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *documentSetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var model documentSetResourceModel
+	diags := req.State.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := model.Timeouts.Delete(ctx, defaultDocumentSetDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	current, diags := model.documentHashes(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Delete every document of the set
+	ids := make([]string, 0, len(current))
+	for id := range current {
+		ids = append(ids, id)
+	}
+	failed := r.forEachDocument(ctx, model.Parallelism.ValueInt64(), ids, func(id string) error {
+		err := r.client.DeleteDocument(ctx, r.documentName(&model, id))
+		if errors.Is(err, client.ErrNotFound) {
+			return nil
+		}
+		return err
+	})
+	if len(failed) > 0 {
+		resp.Diagnostics.AddError(
+			"Error deleting documents",
+			documentSetErrorDetail("delete", failed),
+		)
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+// sync reconciles the branch from the current documents to the ones planned in
+// model, recording the documents that were written or deleted successfully in
+// model.Documents so a failed sync is retried by the next apply. It returns
+// the documents it failed to write and delete, by document ID.
+func (r *documentSetResource) sync(ctx context.Context, model *documentSetResourceModel, current map[string]string) (map[string]error, map[string]error, diag.Diagnostics) {
+	local, diags := model.localDocuments(ctx)
+	if diags.HasError() {
+		return nil, nil, diags
+	}
+
+	// The hashes of a new set are unknown until apply, so only updates can
+	// check the directory against the plan
+	if !model.Documents.IsUnknown() {
+		diags.Append(checkPlannedDocuments(ctx, model, local)...)
+		if diags.HasError() {
+			return nil, nil, diags
+		}
+	}
+
+	added, changed, removed := diffDocuments(local, current)
+	parallelism := model.Parallelism.ValueInt64()
+
+	failedWrites := r.forEachDocument(ctx, parallelism, append(append([]string{}, added...), changed...), func(id string) error {
+		data, err := readFileSHA256Matching(local[id])
+		if err != nil {
+			return err
+		}
+		input := &client.DocumentInput{
+			Content: &client.DocumentContent{
+				MimeType: local[id].MimeType,
+				RawBytes: data,
+			},
+		}
+
+		if _, exists := current[id]; exists {
+			_, err = r.client.UpdateDocument(ctx, r.documentName(model, id), input)
+			return err
+		}
+		_, err = r.client.CreateDocument(ctx, r.branchName(model), id, input)
+		if errors.Is(err, client.ErrAlreadyExists) {
+			// Already on the branch, e.g. created outside of Terraform; the file overwrites it
+			_, err = r.client.UpdateDocument(ctx, r.documentName(model, id), input)
+		}
+		return err
+	})
+
+	failedDeletes := r.forEachDocument(ctx, parallelism, removed, func(id string) error {
+		err := r.client.DeleteDocument(ctx, r.documentName(model, id))
+		if errors.Is(err, client.ErrNotFound) {
+			return nil
+		}
+		return err
+	})
+
+	// Record what the branch holds now: written documents carry their new hash,
+	// failed ones keep their previous state
+	result := make(map[string]string, len(local))
+	for id, hash := range current {
+		result[id] = hash
+	}
+	for id, doc := range local {
+		if _, failed := failedWrites[id]; !failed {
+			result[id] = doc.SHA256
+		}
+	}
+	for _, id := range removed {
+		if _, failed := failedDeletes[id]; !failed {
+			delete(result, id)
+		}
+	}
+
+	var mapDiags diag.Diagnostics
+	model.Documents, mapDiags = types.MapValueFrom(ctx, types.StringType, result)
+	diags.Append(mapDiags...)
+
+	return failedWrites, failedDeletes, diags
+}
+
+// checkPlannedDocuments fails when the directory no longer matches the
+// documents planned in model
+func checkPlannedDocuments(ctx context.Context, model *documentSetResourceModel, local map[string]localDocument) diag.Diagnostics {
+	planned, diags := model.documentHashes(ctx)
+	if diags.HasError() {
+		return diags
+	}
+
+	for id, doc := range local {
+		if planned[id] != doc.SHA256 {
+			diags.AddError(
+				"Documents changed during apply",
+				fmt.Sprintf("The file %s was added or changed after the plan was made. Run terraform apply again to sync the new content.", doc.Path),
+			)
+			return diags
+		}
+	}
+	for id := range planned {
+		if _, exists := local[id]; !exists {
+			diags.AddError(
+				"Documents changed during apply",
+				fmt.Sprintf("The file behind document %q was removed after the plan was made. Run terraform apply again to sync the directory.", id),
+			)
+			return diags
+		}
+	}
+
+	return diags
+}
+
+// forEachDocument runs fn for every document ID with at most parallelism calls
+// in flight and returns the errors by document ID
+func (r *documentSetResource) forEachDocument(ctx context.Context, parallelism int64, ids []string, fn func(id string) error) map[string]error {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		failed = make(map[string]error)
+		sem    = make(chan struct{}, parallelism)
+	)
+
+	for _, id := range ids {
+		if err := ctx.Err(); err != nil {
+			mu.Lock()
+			failed[id] = err
+			mu.Unlock()
+			continue
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(id string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if err := fn(id); err != nil {
+				mu.Lock()
+				failed[id] = err
+				mu.Unlock()
+			}
+		}(id)
+	}
+	wg.Wait()
+
+	return failed
+}
+
+// existingDocuments looks up every document of the set, at most parallelism
+// at a time, and returns the hashes of the ones that still exist. The cost
+// grows with the set, not with the branch.
+func (r *documentSetResource) existingDocuments(ctx context.Context, model *documentSetResourceModel, hashes map[string]string) (map[string]string, error) {
+	ids := make([]string, 0, len(hashes))
+	for id := range hashes {
+		ids = append(ids, id)
+	}
+	failed := r.forEachDocument(ctx, model.Parallelism.ValueInt64(), ids, func(id string) error {
+		_, err := r.client.GetDocument(ctx, r.documentName(model, id))
+		return err
+	})
+
+	existing := make(map[string]string, len(hashes))
+	for id, hash := range hashes {
+		err, found := failed[id]
+		if !found {
+			existing[id] = hash
+			continue
+		}
+		if !errors.Is(err, client.ErrNotFound) {
+			return nil, err
+		}
+	}
+	return existing, nil
+}
+
+// dataStoreName builds the full resource name of the data store
+func (r *documentSetResource) dataStoreName(model *documentSetResourceModel) string {
+	return fmt.Sprintf("projects/%s/locations/%s/collections/%s/dataStores/%s",
+		r.client.Config().ProjectID,
+		r.client.Config().Location,
+		r.client.Config().Collection,
+		model.DataStoreID.ValueString())
+}
+
+// branchName builds the full resource name of the branch
+func (r *documentSetResource) branchName(model *documentSetResourceModel) string {
+	return r.dataStoreName(model) + "/branches/" + model.Branch.ValueString()
+}
+
+// documentName builds the full resource name of a document on the branch
+func (r *documentSetResource) documentName(model *documentSetResourceModel, documentID string) string {
+	return r.branchName(model) + "/documents/" + documentID
+}
+
+// documentHashes returns the documents attribute as a map
+func (m *documentSetResourceModel) documentHashes(ctx context.Context) (map[string]string, diag.Diagnostics) {
+	hashes := map[string]string{}
+	if m.Documents.IsNull() || m.Documents.IsUnknown() {
+		return hashes, nil
+	}
+
+	diags := m.Documents.ElementsAs(ctx, &hashes, false)
+	return hashes, diags
+}
+
+// localDocuments scans the directory for the files to sync
+func (m *documentSetResourceModel) localDocuments(ctx context.Context) (map[string]localDocument, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var patterns []string
+	diags.Append(m.Patterns.ElementsAs(ctx, &patterns, false)...)
+	mimeTypes := map[string]string{}
+	if !m.MimeTypes.IsNull() {
+		diags.Append(m.MimeTypes.ElementsAs(ctx, &mimeTypes, false)...)
+	}
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	documents, err := scanLocalDocuments(m.Directory.ValueString(), patterns, mimeTypes)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("directory"),
+			"Error reading document set directory",
+			err.Error(),
+		)
+		return nil, diags
+	}
+
+	return documents, diags
+}
+
+// readFileSHA256Matching reads a local document, failing if it no longer
+// matches the hash it was planned with
+func readFileSHA256Matching(doc localDocument) ([]byte, error) {
+	hash, data, err := readFileSHA256(doc.Path)
+	if err != nil {
+		return nil, err
+	}
+	if hash != doc.SHA256 {
+		return nil, fmt.Errorf("%s changed during apply", doc.Path)
+	}
+	return data, nil
+}
+
+// documentSetErrorDetail lists the documents a sync failed to write or delete
+func documentSetErrorDetail(action string, failed map[string]error) string {
+	ids := make([]string, 0, len(failed))
+	for id := range failed {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	lines := make([]string, 0, len(ids))
+	for _, id := range ids {
+		lines = append(lines, fmt.Sprintf("- %s: %s", id, clientErrorDetail(failed[id])))
+	}
+	return fmt.Sprintf("Failed to %s %d document(s). The documents that succeeded are recorded in state and the rest are retried by the next apply.\n\n%s",
+		action, len(failed), strings.Join(lines, "\n"))
+}
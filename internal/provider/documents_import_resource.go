@@ -0,0 +1,363 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	client "github.com/vb140772/terraform-provider-gemctl/internal/client"
+)
+
+// Ensure NewDocumentsImportResource returns a resource with the correct interface implementation
+var (
+	_ resource.Resource                     = &documentsImportResource{}
+	_ resource.ResourceWithConfigValidators = &documentsImportResource{}
+)
+
+const (
+	defaultDocumentsImportCreateTimeout = 60 * time.Minute
+	defaultDocumentsImportReadTimeout   = 5 * time.Minute
+)
+
+// documentsImportSourceBlocks are the names of the blocks selecting the source of an import
+var documentsImportSourceBlocks = []string{
+	"gcs_source",
+	"bigquery_source",
+	"cloud_sql_source",
+	"spanner_source",
+	"alloydb_source",
+	"firestore_source",
+	"bigtable_source",
+}
+
+type documentsImportResource struct {
+	client *client.GeminiClient
+}
+
+type documentsImportResourceModel struct {
+	ID                   types.String   `tfsdk:"id"`
+	DataStoreID          types.String   `tfsdk:"data_store_id"`
+	Branch               types.String   `tfsdk:"branch"`
+	ReconciliationMode   types.String   `tfsdk:"reconciliation_mode"`
+	IDField              types.String   `tfsdk:"id_field"`
+	AutoGenerateIDs      types.Bool     `tfsdk:"auto_generate_ids"`
+	ImportErrorGCSPrefix types.String   `tfsdk:"import_error_gcs_prefix"`
+	MaxImportFailures    types.Int64    `tfsdk:"max_import_failures"`
+	Triggers             types.Map      `tfsdk:"triggers"`
+	GCSSource            types.Object   `tfsdk:"gcs_source"`
+	BigQuerySource       types.Object   `tfsdk:"bigquery_source"`
+	CloudSQLSource       types.Object   `tfsdk:"cloud_sql_source"`
+	SpannerSource        types.Object   `tfsdk:"spanner_source"`
+	AlloyDBSource        types.Object   `tfsdk:"alloydb_source"`
+	FirestoreSource      types.Object   `tfsdk:"firestore_source"`
+	BigtableSource       types.Object   `tfsdk:"bigtable_source"`
+	Result               types.Object   `tfsdk:"result"`
+	Timeouts             timeouts.Value `tfsdk:"timeouts"`
+}
+
+func NewDocumentsImportResource(c *client.GeminiClient) resource.Resource {
+	return &documentsImportResource{
+		client: c,
+	}
+}
+
+func (r *documentsImportResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_documents_import"
+}
+
+func (r *documentsImportResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	result := importResultAttribute("Outcome of the import")
+	result.PlanModifiers = []planmodifier.Object{
+		objectplanmodifier.UseStateForUnknown(),
+	}
+
+	blocks := map[string]schema.Block{
+		"gcs_source":       gcsSourceBlock("Cloud Storage files to import"),
+		"bigquery_source":  bigQuerySourceBlock("BigQuery table to import"),
+		"cloud_sql_source": cloudSQLSourceBlock("Cloud SQL table to import"),
+		"spanner_source":   spannerSourceBlock("Spanner table to import"),
+		"alloydb_source":   alloyDBSourceBlock("AlloyDB table to import"),
+		"firestore_source": firestoreSourceBlock("Firestore collection to import"),
+		"bigtable_source":  bigtableSourceBlock("Bigtable table to import"),
+	}
+	for name, block := range blocks {
+		// Any change to the source runs a new import
+		source := block.(schema.SingleNestedBlock)
+		source.PlanModifiers = []planmodifier.Object{
+			objectplanmodifier.RequiresReplace(),
+		}
+		blocks[name] = source
+	}
+	blocks["timeouts"] = timeouts.Block(ctx, timeouts.Opts{
+		Create: true,
+		Read:   true,
+	})
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Imports documents into a data store in Google Gemini Enterprise from Cloud Storage, BigQuery, Cloud SQL, Spanner, AlloyDB, Firestore or Bigtable, and records the outcome. " +
+			"Exactly one source block must be set. Changing the source, any import setting or `triggers` runs a new import; destroying the resource leaves the imported documents in place.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Name of the import operation",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"data_store_id": schema.StringAttribute{
+				Required:    true,
+				Description: "ID of the data store to import the documents into",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"branch": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(client.DefaultBranch),
+				Description: "Branch of the data store the documents are imported into. Defaults to default_branch.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"reconciliation_mode": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("INCREMENTAL"),
+				Description: "How imported documents are reconciled with existing ones: INCREMENTAL adds and updates documents, FULL also deletes documents missing from the source. Defaults to INCREMENTAL.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("INCREMENTAL", "FULL"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id_field": schema.StringAttribute{
+				Optional:    true,
+				Description: "Field or column of structured sources holding the ID of each document",
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("auto_generate_ids")),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"auto_generate_ids": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Derive the ID of each document from its content, for structured sources without an ID field",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"import_error_gcs_prefix": schema.StringAttribute{
+				Optional:    true,
+				Description: "GCS prefix (e.g., gs://bucket/import-errors/) the errors of every document that fails to import are written to",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(gcsPrefixPattern, "must be a Cloud Storage location of the form gs://bucket or gs://bucket/path/"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"max_import_failures": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Largest number of documents allowed to fail. When more fail, the apply reports an error and the import is run again by the next apply. Unset accepts any number of failures.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Arbitrary values that run a new import when they change, such as a build ID or the hash of a manifest listing the source files",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"result": result,
+		},
+		Blocks: blocks,
+	}
+}
+
+func (r *documentsImportResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	sources := make([]path.Expression, 0, len(documentsImportSourceBlocks))
+	for _, name := range documentsImportSourceBlocks {
+		sources = append(sources, path.MatchRoot(name))
+	}
+
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(sources...),
+	}
+}
+
+func (r *documentsImportResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model documentsImportResourceModel
+	diags := req.Plan.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := model.Timeouts.Create(ctx, defaultDocumentsImportCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	source, diags := model.importSource(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Import the documents
+	importResult, err := r.client.ImportDocuments(
+		ctx,
+		r.dataStoreName(&model),
+		model.Branch.ValueString(),
+		source,
+		model.ReconciliationMode.ValueString(),
+		model.ImportErrorGCSPrefix.ValueString(),
+	)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error importing documents",
+			clientErrorDetail(err),
+		)
+		return
+	}
+
+	// An import with too many failures is not recorded, so the next apply runs it again
+	if detail, exceeded := importFailuresError(importResult, model.MaxImportFailures); exceeded {
+		resp.Diagnostics.AddError("Too many documents failed to import", detail)
+		return
+	}
+	if detail, failed := importFailuresWarning(importResult); failed {
+		resp.Diagnostics.AddWarning("Some documents failed to import", detail)
+	}
+
+	model.ID = types.StringValue(importResult.OperationName)
+	model.Result, diags = importResultValue(ctx, importResult)
+	resp.Diagnostics.Append(diags...)
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *documentsImportResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var model documentsImportResourceModel
+	diags := req.State.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := model.Timeouts.Read(ctx, defaultDocumentsImportReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	// The import itself is finished and never changes; only check that the
+	// data store holding the documents still exists
+	_, err := r.client.GetDataStoreDetails(ctx, r.dataStoreName(&model))
+	if errors.Is(err, client.ErrNotFound) {
+		resp.Diagnostics.AddWarning(
+			"Data store no longer exists",
+			fmt.Sprintf("The data store %q was not found and the import has been removed from the Terraform state. "+
+				"It was probably deleted outside of Terraform; the next apply will import the documents again.",
+				model.DataStoreID.ValueString()),
+		)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading data store",
+			clientErrorDetail(err),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *documentsImportResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Every setting that affects the import forces a new one; the rest only
+	// applies to future imports and is stored as planned
+	var model documentsImportResourceModel
+	diags := req.Plan.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *documentsImportResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Imported documents stay in the data store; there is nothing to delete
+	resp.State.RemoveResource(ctx)
+}
+
+// dataStoreName builds the full resource name of the data store
+func (r *documentsImportResource) dataStoreName(model *documentsImportResourceModel) string {
+	return fmt.Sprintf("projects/%s/locations/%s/collections/%s/dataStores/%s",
+		r.client.Config().ProjectID,
+		r.client.Config().Location,
+		r.client.Config().Collection,
+		model.DataStoreID.ValueString())
+}
+
+// importSource converts the configured source block to its client form
+func (m *documentsImportResourceModel) importSource(ctx context.Context) (*client.ImportSource, diag.Diagnostics) {
+	var diags, sourceDiags diag.Diagnostics
+	source := &client.ImportSource{
+		IDField:         m.IDField.ValueString(),
+		AutoGenerateIDs: m.AutoGenerateIDs.ValueBool(),
+	}
+
+	source.GCS, sourceDiags = gcsSourceFromObject(ctx, m.GCSSource)
+	diags.Append(sourceDiags...)
+	source.BigQuery, sourceDiags = bigQuerySourceFromObject(ctx, m.BigQuerySource)
+	diags.Append(sourceDiags...)
+	source.CloudSQL, sourceDiags = cloudSQLSourceFromObject(ctx, m.CloudSQLSource)
+	diags.Append(sourceDiags...)
+	source.Spanner, sourceDiags = spannerSourceFromObject(ctx, m.SpannerSource)
+	diags.Append(sourceDiags...)
+	source.AlloyDB, sourceDiags = alloyDBSourceFromObject(ctx, m.AlloyDBSource)
+	diags.Append(sourceDiags...)
+	source.Firestore, sourceDiags = firestoreSourceFromObject(ctx, m.FirestoreSource)
+	diags.Append(sourceDiags...)
+	source.Bigtable, sourceDiags = bigtableSourceFromObject(ctx, m.BigtableSource)
+	diags.Append(sourceDiags...)
+
+	return source, diags
+}
@@ -16,12 +16,14 @@ type engineDataSource struct {
 }
 
 type engineDataSourceModel struct {
-	EngineID    types.String `tfsdk:"engine_id"`
-	Name        types.String `tfsdk:"name"`
-	DisplayName types.String `tfsdk:"display_name"`
-	SolutionType types.String `tfsdk:"solution_type"`
+	EngineID         types.String `tfsdk:"engine_id"`
+	Name             types.String `tfsdk:"name"`
+	DisplayName      types.String `tfsdk:"display_name"`
+	SolutionType     types.String `tfsdk:"solution_type"`
 	IndustryVertical types.String `tfsdk:"industry_vertical"`
-	DataStoreIds types.List `tfsdk:"data_store_ids"`
+	AppType          types.String `tfsdk:"app_type"`
+	CompanyName      types.String `tfsdk:"company_name"`
+	DataStoreIds     types.List   `tfsdk:"data_store_ids"`
 }
 
 func NewEngineDataSource(c *client.GeminiClient) datasource.DataSource {
@@ -57,6 +59,14 @@ func (d *engineDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 				Computed:    true,
 				Description: "Industry vertical of the engine",
 			},
+			"app_type": schema.StringAttribute{
+				Computed:    true,
+				Description: "Application type of the engine",
+			},
+			"company_name": schema.StringAttribute{
+				Computed:    true,
+				Description: "Company name associated with the engine",
+			},
 			"data_store_ids": schema.ListAttribute{
 				ElementType: types.StringType,
 				Computed:    true,
@@ -82,11 +92,11 @@ func (d *engineDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		model.EngineID.ValueString())
 
 	// Read the engine
-	engine, err := d.client.GetEngineDetails(engineName)
+	engine, err := d.client.GetEngineDetails(ctx, engineName)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading engine",
-			fmt.Sprintf("Failed to read engine: %v", err),
+			clientErrorDetail(err),
 		)
 		return
 	}
@@ -95,6 +105,8 @@ func (d *engineDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	model.DisplayName = types.StringValue(engine.DisplayName)
 	model.SolutionType = types.StringValue(engine.SolutionType)
 	model.IndustryVertical = types.StringValue(engine.IndustryVertical)
+	model.AppType = types.StringValue(engineAppType(engine))
+	model.CompanyName = engineCompanyName(engine)
 
 	// Convert data store IDs to list
 	dataStoreList := []types.String{}
@@ -107,4 +119,3 @@ func (d *engineDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	diags = resp.State.Set(ctx, model)
 	resp.Diagnostics.Append(diags...)
 }
-
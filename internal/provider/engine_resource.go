@@ -2,28 +2,67 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 
 	client "github.com/vb140772/terraform-provider-gemctl/internal/client"
 )
 
 // Ensure NewEngineResource returns a resource with the correct interface implementation
-var _ resource.Resource = &engineResource{}
+var (
+	_ resource.Resource                = &engineResource{}
+	_ resource.ResourceWithImportState = &engineResource{}
+)
+
+const (
+	defaultEngineCreateTimeout = 20 * time.Minute
+	defaultEngineReadTimeout   = 5 * time.Minute
+	defaultEngineUpdateTimeout = 20 * time.Minute
+	defaultEngineDeleteTimeout = 20 * time.Minute
+)
 
 type engineResource struct {
 	client *client.GeminiClient
 }
 
 type engineResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	EngineID     types.String `tfsdk:"engine_id"`
-	DisplayName  types.String `tfsdk:"display_name"`
-	DataStores   types.List   `tfsdk:"data_stores"`
-	Name         types.String `tfsdk:"name"`
+	ID                 types.String   `tfsdk:"id"`
+	EngineID           types.String   `tfsdk:"engine_id"`
+	DisplayName        types.String   `tfsdk:"display_name"`
+	DataStores         types.List     `tfsdk:"data_stores"`
+	SolutionType       types.String   `tfsdk:"solution_type"`
+	IndustryVertical   types.String   `tfsdk:"industry_vertical"`
+	AppType            types.String   `tfsdk:"app_type"`
+	CompanyName        types.String   `tfsdk:"company_name"`
+	SearchEngineConfig types.Object   `tfsdk:"search_engine_config"`
+	Name               types.String   `tfsdk:"name"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
+}
+
+type searchEngineConfigModel struct {
+	SearchTier   types.String `tfsdk:"search_tier"`
+	SearchAddOns types.List   `tfsdk:"search_add_ons"`
+}
+
+var searchEngineConfigAttrTypes = map[string]attr.Type{
+	"search_tier":    types.StringType,
+	"search_add_ons": types.ListType{ElemType: types.StringType},
 }
 
 func NewEngineResource(c *client.GeminiClient) resource.Resource {
@@ -36,16 +75,22 @@ func (r *engineResource) Metadata(_ context.Context, req resource.MetadataReques
 	resp.TypeName = req.ProviderTypeName + "_engine"
 }
 
-func (r *engineResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *engineResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Manages a search engine in Google Gemini Enterprise. An engine can be connected to multiple data stores to provide search capabilities.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"engine_id": schema.StringAttribute{
 				Required:    true,
-				Description: "Unique identifier for the engine",
+				Description: "Unique identifier for the engine. Changing this forces a new engine to be created.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"display_name": schema.StringAttribute{
 				Required:    true,
@@ -54,12 +99,95 @@ func (r *engineResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 			"data_stores": schema.ListAttribute{
 				ElementType: types.StringType,
 				Optional:    true,
-				Description: "List of data store IDs to connect to this engine",
+				Description: "List of data store IDs to connect to this engine. Changes are applied in place where the API allows it for the engine's solution type.",
+			},
+			"solution_type": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(client.DefaultEngineSolutionType),
+				Description: "Solution type of the engine: SOLUTION_TYPE_SEARCH, SOLUTION_TYPE_RECOMMENDATION, SOLUTION_TYPE_CHAT or SOLUTION_TYPE_GENERATIVE_CHAT. Defaults to SOLUTION_TYPE_SEARCH. Changing this forces a new engine to be created.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						"SOLUTION_TYPE_SEARCH",
+						"SOLUTION_TYPE_RECOMMENDATION",
+						"SOLUTION_TYPE_CHAT",
+						"SOLUTION_TYPE_GENERATIVE_CHAT",
+					),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"industry_vertical": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(client.DefaultEngineIndustryVertical),
+				Description: "Industry vertical of the engine: GENERIC, MEDIA or HEALTHCARE_FHIR. It must match the vertical of the connected data stores. Defaults to GENERIC. Changing this forces a new engine to be created.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("GENERIC", "MEDIA", "HEALTHCARE_FHIR"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"app_type": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(client.DefaultEngineAppType),
+				Description: "Application type of the engine: APP_TYPE_INTRANET for intranet search and Agentspace, or APP_TYPE_UNSPECIFIED. Defaults to APP_TYPE_INTRANET. Changing this forces a new engine to be created.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("APP_TYPE_INTRANET", "APP_TYPE_UNSPECIFIED"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"company_name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Name of the company, business or entity associated with the engine (common_config.company_name). Setting it may improve LLM related features. Changes are applied in place; removing it clears the company name of the engine.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
 			},
 			"name": schema.StringAttribute{
 				Computed:    true,
 				Description: "Full resource name of the engine",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"search_engine_config": schema.SingleNestedBlock{
+				Description: "Search tier and add-ons of a SOLUTION_TYPE_SEARCH engine. Changes are applied in place. When omitted, new engines get the standard tier without add-ons and existing engines keep their settings. terraform import records the engine's settings when it reports any.",
+				Attributes: map[string]schema.Attribute{
+					"search_tier": schema.StringAttribute{
+						Optional:    true,
+						Computed:    true,
+						Description: "Search feature tier: SEARCH_TIER_STANDARD or SEARCH_TIER_ENTERPRISE. When unset, the engine's tier is used, SEARCH_TIER_STANDARD for new engines.",
+						Validators: []validator.String{
+							stringvalidator.OneOf("SEARCH_TIER_STANDARD", "SEARCH_TIER_ENTERPRISE"),
+						},
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+						},
+					},
+					"search_add_ons": schema.ListAttribute{
+						ElementType: types.StringType,
+						Optional:    true,
+						Description: "Add-ons enabled on the engine, e.g. SEARCH_ADD_ON_LLM",
+						Validators: []validator.List{
+							listvalidator.ValueStringsAre(stringvalidator.OneOf("SEARCH_ADD_ON_LLM")),
+						},
+					},
+				},
 			},
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
 }
@@ -72,6 +200,15 @@ func (r *engineResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	createTimeout, diags := model.Timeouts.Create(ctx, defaultEngineCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	// Convert data stores list
 	var dataStoreIDs []string
 	if !model.DataStores.IsNull() {
@@ -80,17 +217,30 @@ func (r *engineResource) Create(ctx context.Context, req resource.CreateRequest,
 		}
 	}
 
+	searchEngineConfig, diags := searchEngineConfigFromObject(ctx, model.SearchEngineConfig)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Create the engine
 	result, err := r.client.CreateSearchEngine(
+		ctx,
 		model.EngineID.ValueString(),
 		model.DisplayName.ValueString(),
 		dataStoreIDs,
-		"SEARCH_TIER_ENTERPRISE",
+		&client.EngineOptions{
+			SolutionType:       model.SolutionType.ValueString(),
+			IndustryVertical:   model.IndustryVertical.ValueString(),
+			AppType:            model.AppType.ValueString(),
+			CompanyName:        model.CompanyName.ValueString(),
+			SearchEngineConfig: searchEngineConfig,
+		},
 	)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating engine",
-			fmt.Sprintf("Failed to create engine: %v", err),
+			clientErrorDetail(err),
 		)
 		return
 	}
@@ -98,6 +248,9 @@ func (r *engineResource) Create(ctx context.Context, req resource.CreateRequest,
 	model.ID = types.StringValue(model.EngineID.ValueString())
 	model.Name = types.StringValue(result.EngineName)
 
+	model.SearchEngineConfig, diags = defaultSearchTier(ctx, model.SearchEngineConfig)
+	resp.Diagnostics.Append(diags...)
+
 	diags = resp.State.Set(ctx, model)
 	resp.Diagnostics.Append(diags...)
 }
@@ -110,6 +263,15 @@ func (r *engineResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
+	readTimeout, diags := model.Timeouts.Read(ctx, defaultEngineReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	// Build the full engine name
 	engineName := fmt.Sprintf("projects/%s/locations/%s/collections/%s/engines/%s",
 		r.client.Config().ProjectID,
@@ -118,18 +280,47 @@ func (r *engineResource) Read(ctx context.Context, req resource.ReadRequest, res
 		model.EngineID.ValueString())
 
 	// Read the engine
-	engine, err := r.client.GetEngineDetails(engineName)
+	engine, err := r.client.GetEngineDetails(ctx, engineName)
+	if errors.Is(err, client.ErrNotFound) {
+		// Deleted outside of Terraform; dropping it from state plans a recreate
+		resp.Diagnostics.AddWarning(
+			"Engine no longer exists",
+			fmt.Sprintf("The engine %q was not found and has been removed from the Terraform state. "+
+				"It was probably deleted outside of Terraform; the next apply will create it again.",
+				model.EngineID.ValueString()),
+		)
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading engine",
-			fmt.Sprintf("Failed to read engine: %v", err),
+			clientErrorDetail(err),
 		)
 		return
 	}
 
+	model.ID = types.StringValue(model.EngineID.ValueString())
 	model.DisplayName = types.StringValue(engine.DisplayName)
+	model.SolutionType = types.StringValue(engine.SolutionType)
+	model.IndustryVertical = types.StringValue(engine.IndustryVertical)
+	model.AppType = types.StringValue(engineAppType(engine))
+	model.CompanyName = engineCompanyName(engine)
 	model.Name = types.StringValue(engine.Name)
 
+	model.SearchEngineConfig, diags = refreshSearchEngineConfig(ctx, model.SearchEngineConfig, engine.SearchEngineConfig)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Data stores attached or detached outside of Terraform show up as drift
+	model.DataStores, diags = refreshStringList(ctx, model.DataStores, engine.DataStoreIds)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	diags = resp.State.Set(ctx, model)
 	resp.Diagnostics.Append(diags...)
 }
@@ -142,30 +333,92 @@ func (r *engineResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	// Convert data stores list
-	var dataStoreIDs []string
-	if !model.DataStores.IsNull() {
-		for _, ds := range model.DataStores.Elements() {
-			dataStoreIDs = append(dataStoreIDs, ds.(types.String).ValueString())
+	updateTimeout, diags := model.Timeouts.Update(ctx, defaultEngineUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	var state engineResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only send the fields that changed
+	update := &client.EngineUpdate{}
+	if !model.DisplayName.Equal(state.DisplayName) {
+		displayName := model.DisplayName.ValueString()
+		update.DisplayName = &displayName
+	}
+	if !model.DataStores.Equal(state.DataStores) {
+		var dataStoreIDs, stateDataStoreIDs []string
+		if !model.DataStores.IsNull() {
+			for _, ds := range model.DataStores.Elements() {
+				dataStoreIDs = append(dataStoreIDs, ds.(types.String).ValueString())
+			}
+		}
+		if !state.DataStores.IsNull() {
+			for _, ds := range state.DataStores.Elements() {
+				stateDataStoreIDs = append(stateDataStoreIDs, ds.(types.String).ValueString())
+			}
+		}
+		// Reordering the list does not change the attached data stores
+		if !sameStrings(dataStoreIDs, stateDataStoreIDs) {
+			update.DataStoreIDs = &dataStoreIDs
 		}
 	}
 
-	// Update the engine (create new version with updated config)
-	result, err := r.client.CreateSearchEngine(
-		model.EngineID.ValueString(),
-		model.DisplayName.ValueString(),
-		dataStoreIDs,
-		"SEARCH_TIER_ENTERPRISE",
-	)
+	// An unset company_name clears the engine's company name
+	if !model.CompanyName.Equal(state.CompanyName) {
+		companyName := model.CompanyName.ValueString()
+		update.CompanyName = &companyName
+	}
+
+	// Removing the block leaves the engine's search settings as they are
+	if !model.SearchEngineConfig.IsNull() && !model.SearchEngineConfig.Equal(state.SearchEngineConfig) {
+		update.SearchEngineConfig, diags = searchEngineConfigFromObject(ctx, model.SearchEngineConfig)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Build the full engine name
+	engineName := fmt.Sprintf("projects/%s/locations/%s/collections/%s/engines/%s",
+		r.client.Config().ProjectID,
+		r.client.Config().Location,
+		r.client.Config().Collection,
+		model.EngineID.ValueString())
+
+	// Update the engine in place
+	engine, err := r.client.PatchEngine(ctx, engineName, update)
+	if err != nil && update.SearchEngineConfig != nil && errors.Is(err, client.ErrInvalidArgument) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("search_engine_config"),
+			"Error updating engine search settings",
+			clientErrorDetail(err)+"\n\nThe API rejected the new search tier or add-ons for this engine. "+
+				"Revert search_engine_config, or recreate the engine with terraform apply -replace to apply them.",
+		)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating engine",
-			fmt.Sprintf("Failed to update engine: %v", err),
+			clientErrorDetail(err),
 		)
 		return
 	}
 
-	model.Name = types.StringValue(result.EngineName)
+	model.Name = types.StringValue(engine.Name)
+	model.CompanyName = engineCompanyName(engine)
+
+	model.SearchEngineConfig, diags = defaultSearchTier(ctx, model.SearchEngineConfig)
+	resp.Diagnostics.Append(diags...)
 
 	diags = resp.State.Set(ctx, model)
 	resp.Diagnostics.Append(diags...)
@@ -179,6 +432,15 @@ func (r *engineResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	deleteTimeout, diags := model.Timeouts.Delete(ctx, defaultEngineDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	// Build the full engine name
 	engineName := fmt.Sprintf("projects/%s/locations/%s/collections/%s/engines/%s",
 		r.client.Config().ProjectID,
@@ -187,11 +449,11 @@ func (r *engineResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		model.EngineID.ValueString())
 
 	// Delete the engine
-	_, err := r.client.DeleteEngine(engineName)
+	err := r.client.DeleteEngine(ctx, engineName)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error deleting engine",
-			fmt.Sprintf("Failed to delete engine: %v", err),
+			clientErrorDetail(err),
 		)
 		return
 	}
@@ -199,3 +461,139 @@ func (r *engineResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	resp.State.RemoveResource(ctx)
 }
 
+func (r *engineResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	engineID, err := shortIDFromImportID(r.client.Config(), req.ID, "engines")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid engine import ID",
+			err.Error(),
+		)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, defaultEngineReadTimeout)
+	defer cancel()
+
+	// Build the full engine name
+	engineName := fmt.Sprintf("projects/%s/locations/%s/collections/%s/engines/%s",
+		r.client.Config().ProjectID,
+		r.client.Config().Location,
+		r.client.Config().Collection,
+		engineID)
+
+	engine, err := r.client.GetEngineDetails(ctx, engineName)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error importing engine",
+			clientErrorDetail(err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), engineID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("engine_id"), engineID)...)
+
+	// Read only refreshes search_engine_config when it is in state, so record
+	// the engine's search settings here when it reports any
+	if engine.SearchEngineConfig != nil {
+		searchEngineConfig, diags := refreshSearchEngineConfig(ctx, types.ObjectValueMust(searchEngineConfigAttrTypes, map[string]attr.Value{
+			"search_tier":    types.StringNull(),
+			"search_add_ons": types.ListNull(types.StringType),
+		}), engine.SearchEngineConfig)
+		resp.Diagnostics.Append(diags...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("search_engine_config"), searchEngineConfig)...)
+	}
+}
+
+// searchEngineConfigFromObject converts the search_engine_config block to its
+// client form, returning nil when the block is not set
+func searchEngineConfigFromObject(ctx context.Context, obj types.Object) (*client.SearchEngineConfig, diag.Diagnostics) {
+	if obj.IsNull() || obj.IsUnknown() {
+		return nil, nil
+	}
+
+	var config searchEngineConfigModel
+	diags := obj.As(ctx, &config, basetypes.ObjectAsOptions{})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	result := &client.SearchEngineConfig{
+		SearchTier: config.SearchTier.ValueString(),
+	}
+	if !config.SearchAddOns.IsNull() && !config.SearchAddOns.IsUnknown() {
+		diags.Append(config.SearchAddOns.ElementsAs(ctx, &result.SearchAddOns, false)...)
+	}
+
+	return result, diags
+}
+
+// refreshSearchEngineConfig updates the search_engine_config block from the
+// engine. An omitted block leaves the engine's search settings as they are,
+// so the block is only refreshed when already in state.
+func refreshSearchEngineConfig(ctx context.Context, current types.Object, remote *client.SearchEngineConfig) (types.Object, diag.Diagnostics) {
+	if current.IsNull() || current.IsUnknown() {
+		return current, nil
+	}
+
+	var config searchEngineConfigModel
+	diags := current.As(ctx, &config, basetypes.ObjectAsOptions{})
+	if diags.HasError() {
+		return current, diags
+	}
+
+	// The API omits the config for engines on the default tier
+	if remote == nil {
+		remote = &client.SearchEngineConfig{}
+	}
+	config.SearchTier = types.StringValue(client.DefaultSearchTier)
+	if remote.SearchTier != "" {
+		config.SearchTier = types.StringValue(remote.SearchTier)
+	}
+
+	var addOnDiags diag.Diagnostics
+	config.SearchAddOns, addOnDiags = refreshStringList(ctx, config.SearchAddOns, remote.SearchAddOns)
+	diags.Append(addOnDiags...)
+	if diags.HasError() {
+		return current, diags
+	}
+
+	obj, objDiags := types.ObjectValueFrom(ctx, searchEngineConfigAttrTypes, config)
+	diags.Append(objDiags...)
+	return obj, diags
+}
+
+// defaultSearchTier fills in the tier the API applies when search_tier is
+// left unset in a configured search_engine_config block
+func defaultSearchTier(ctx context.Context, obj types.Object) (types.Object, diag.Diagnostics) {
+	if obj.IsNull() || obj.IsUnknown() {
+		return obj, nil
+	}
+
+	var config searchEngineConfigModel
+	diags := obj.As(ctx, &config, basetypes.ObjectAsOptions{})
+	if diags.HasError() || !config.SearchTier.IsUnknown() {
+		return obj, diags
+	}
+
+	config.SearchTier = types.StringValue(client.DefaultSearchTier)
+	result, objDiags := types.ObjectValueFrom(ctx, searchEngineConfigAttrTypes, config)
+	diags.Append(objDiags...)
+	return result, diags
+}
+
+// engineAppType returns the app type of an engine; the API omits it when unspecified
+func engineAppType(engine *client.Engine) string {
+	if engine.AppType == "" {
+		return "APP_TYPE_UNSPECIFIED"
+	}
+	return engine.AppType
+}
+
+// engineCompanyName returns the company name of an engine, or null when none is set
+func engineCompanyName(engine *client.Engine) types.String {
+	if companyName, ok := engine.CommonConfig["companyName"].(string); ok && companyName != "" {
+		return types.StringValue(companyName)
+	}
+	return types.StringNull()
+}
@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	client "github.com/vb140772/terraform-provider-gemctl/internal/client"
+)
+
+// resourceNamePattern matches full resource names such as
+// projects/my-project/locations/global/collections/default_collection/engines/my-engine
+var resourceNamePattern = regexp.MustCompile(`^projects/([^/]+)/locations/([^/]+)/collections/([^/]+)/([A-Za-z]+)/([^/]+)$`)
+
+// shortIDFromImportID accepts either a short resource ID or a full resource name
+// of the given collection type (e.g. "engines", "dataStores") and returns the
+// short ID. Full names must belong to the location and collection the provider
+// is configured for, since every API call is scoped to them.
+func shortIDFromImportID(config *client.Config, importID, resourceType string) (string, error) {
+	if !strings.Contains(importID, "/") {
+		if importID == "" {
+			return "", fmt.Errorf("the import ID must not be empty")
+		}
+		return importID, nil
+	}
+
+	match := resourceNamePattern.FindStringSubmatch(importID)
+	if match == nil || match[4] != resourceType {
+		return "", fmt.Errorf("expected a short ID or a name of the form "+
+			"projects/{project}/locations/{location}/collections/{collection}/%s/{id}, got %q", resourceType, importID)
+	}
+
+	project, location, collection, id := match[1], match[2], match[3], match[5]
+
+	// The API reports names with the project number, which cannot be compared
+	// to a configured project ID, so only project IDs are checked
+	if !isProjectNumber(project) && project != config.ProjectID {
+		return "", fmt.Errorf("%q belongs to project %q but the provider is configured for project %q", importID, project, config.ProjectID)
+	}
+	if location != config.Location {
+		return "", fmt.Errorf("%q is in location %q but the provider is configured for location %q", importID, location, config.Location)
+	}
+	if collection != config.Collection {
+		return "", fmt.Errorf("%q is in collection %q but the provider is configured for collection %q", importID, collection, config.Collection)
+	}
+
+	return id, nil
+}
+
+// isProjectNumber reports whether a project segment is a numeric project number
+func isProjectNumber(project string) bool {
+	for _, r := range project {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return project != ""
+}
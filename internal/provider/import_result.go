@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	client "github.com/vb140772/terraform-provider-gemctl/internal/client"
+)
+
+type importResultModel struct {
+	OperationName  types.String `tfsdk:"operation_name"`
+	SuccessCount   types.Int64  `tfsdk:"success_count"`
+	FailureCount   types.Int64  `tfsdk:"failure_count"`
+	TotalCount     types.Int64  `tfsdk:"total_count"`
+	ErrorSamples   types.List   `tfsdk:"error_samples"`
+	ErrorGCSPrefix types.String `tfsdk:"error_gcs_prefix"`
+}
+
+var importResultAttrTypes = map[string]attr.Type{
+	"operation_name":   types.StringType,
+	"success_count":    types.Int64Type,
+	"failure_count":    types.Int64Type,
+	"total_count":      types.Int64Type,
+	"error_samples":    types.ListType{ElemType: types.StringType},
+	"error_gcs_prefix": types.StringType,
+}
+
+// importResultAttribute returns the schema of a computed attribute holding the
+// outcome of a document import
+func importResultAttribute(description string) schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Computed:    true,
+		Description: description,
+		Attributes: map[string]schema.Attribute{
+			"operation_name": schema.StringAttribute{
+				Computed:    true,
+				Description: "Name of the long-running import operation",
+			},
+			"success_count": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Number of documents imported successfully",
+			},
+			"failure_count": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Number of documents that failed to import",
+			},
+			"total_count": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Total number of documents processed by the import",
+			},
+			"error_samples": schema.ListAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "Error messages of a sample of the documents that failed to import",
+			},
+			"error_gcs_prefix": schema.StringAttribute{
+				Computed:    true,
+				Description: "GCS location the full list of import errors was written to, when an error prefix was configured",
+			},
+		},
+	}
+}
+
+// importResultValue converts the outcome of an import to its attribute value
+func importResultValue(ctx context.Context, result *client.ImportResult) (types.Object, diag.Diagnostics) {
+	if result == nil {
+		return types.ObjectNull(importResultAttrTypes), nil
+	}
+
+	errorSamples, diags := types.ListValueFrom(ctx, types.StringType, result.ErrorSamples)
+	if diags.HasError() {
+		return types.ObjectNull(importResultAttrTypes), diags
+	}
+
+	obj, objDiags := types.ObjectValueFrom(ctx, importResultAttrTypes, importResultModel{
+		OperationName:  types.StringValue(result.OperationName),
+		SuccessCount:   types.Int64Value(result.SuccessCount),
+		FailureCount:   types.Int64Value(result.FailureCount),
+		TotalCount:     types.Int64Value(result.TotalCount),
+		ErrorSamples:   errorSamples,
+		ErrorGCSPrefix: stringValueOrNull(result.ErrorGCSPrefix),
+	})
+	diags.Append(objDiags...)
+	return obj, diags
+}
+
+// importFailuresError reports an import with more failed documents than
+// maxFailures allows. A null maxFailures accepts any number of failures.
+func importFailuresError(result *client.ImportResult, maxFailures types.Int64) (string, bool) {
+	if maxFailures.IsNull() || maxFailures.IsUnknown() || result.FailureCount <= maxFailures.ValueInt64() {
+		return "", false
+	}
+
+	detail := fmt.Sprintf("Import operation %s failed for %d of %d documents, more than the %d allowed by max_import_failures.",
+		result.OperationName, result.FailureCount, result.TotalCount, maxFailures.ValueInt64())
+	return detail + importErrorDetails(result), true
+}
+
+// importFailuresWarning reports an import in which some documents failed
+// without exceeding the allowed number of failures
+func importFailuresWarning(result *client.ImportResult) (string, bool) {
+	if result.FailureCount == 0 {
+		return "", false
+	}
+
+	detail := fmt.Sprintf("Import operation %s failed for %d of %d documents.",
+		result.OperationName, result.FailureCount, result.TotalCount)
+	return detail + importErrorDetails(result), true
+}
+
+// importErrorDetails describes where the errors of an import can be found
+func importErrorDetails(result *client.ImportResult) string {
+	var details string
+	if len(result.ErrorSamples) > 0 {
+		details += "\n\nSample errors:\n- " + strings.Join(result.ErrorSamples, "\n- ")
+	}
+	if result.ErrorGCSPrefix != "" {
+		details += "\n\nThe errors of every failed document were written to " + result.ErrorGCSPrefix + "."
+	} else {
+		details += "\n\nSet import_error_gcs_prefix to write the errors of every failed document to GCS."
+	}
+	return details
+}
+
+// stringValueOrNull returns a string value, or null when s is empty
+func stringValueOrNull(s string) types.String {
+	if s == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(s)
+}
@@ -0,0 +1,508 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+
+	client "github.com/vb140772/terraform-provider-gemctl/internal/client"
+)
+
+// partitionDatePattern matches dates of the form YYYY-MM-DD
+var partitionDatePattern = regexp.MustCompile(`^\d{4}-(0[1-9]|1[0-2])-(0[1-9]|[12]\d|3[01])$`)
+
+type gcsSourceModel struct {
+	InputURIs  types.List   `tfsdk:"input_uris"`
+	DataSchema types.String `tfsdk:"data_schema"`
+}
+
+type bigQuerySourceModel struct {
+	ProjectID     types.String `tfsdk:"project_id"`
+	DatasetID     types.String `tfsdk:"dataset_id"`
+	TableID       types.String `tfsdk:"table_id"`
+	PartitionDate types.String `tfsdk:"partition_date"`
+	DataSchema    types.String `tfsdk:"data_schema"`
+}
+
+type cloudSQLSourceModel struct {
+	ProjectID     types.String `tfsdk:"project_id"`
+	InstanceID    types.String `tfsdk:"instance_id"`
+	DatabaseID    types.String `tfsdk:"database_id"`
+	TableID       types.String `tfsdk:"table_id"`
+	GCSStagingDir types.String `tfsdk:"gcs_staging_dir"`
+	Offload       types.Bool   `tfsdk:"offload"`
+}
+
+type spannerSourceModel struct {
+	ProjectID       types.String `tfsdk:"project_id"`
+	InstanceID      types.String `tfsdk:"instance_id"`
+	DatabaseID      types.String `tfsdk:"database_id"`
+	TableID         types.String `tfsdk:"table_id"`
+	EnableDataBoost types.Bool   `tfsdk:"enable_data_boost"`
+}
+
+type alloyDBSourceModel struct {
+	ProjectID     types.String `tfsdk:"project_id"`
+	LocationID    types.String `tfsdk:"location_id"`
+	ClusterID     types.String `tfsdk:"cluster_id"`
+	DatabaseID    types.String `tfsdk:"database_id"`
+	TableID       types.String `tfsdk:"table_id"`
+	GCSStagingDir types.String `tfsdk:"gcs_staging_dir"`
+}
+
+type firestoreSourceModel struct {
+	ProjectID     types.String `tfsdk:"project_id"`
+	DatabaseID    types.String `tfsdk:"database_id"`
+	CollectionID  types.String `tfsdk:"collection_id"`
+	GCSStagingDir types.String `tfsdk:"gcs_staging_dir"`
+}
+
+type bigtableSourceModel struct {
+	ProjectID    types.String `tfsdk:"project_id"`
+	InstanceID   types.String `tfsdk:"instance_id"`
+	TableID      types.String `tfsdk:"table_id"`
+	KeyFieldName types.String `tfsdk:"key_field_name"`
+	ColumnFamily types.Set    `tfsdk:"column_family"`
+}
+
+type bigtableColumnFamilyModel struct {
+	Name      types.String `tfsdk:"name"`
+	FieldName types.String `tfsdk:"field_name"`
+	Type      types.String `tfsdk:"type"`
+	Encoding  types.String `tfsdk:"encoding"`
+	Column    types.List   `tfsdk:"column"`
+}
+
+type bigtableColumnModel struct {
+	Qualifier types.String `tfsdk:"qualifier"`
+	FieldName types.String `tfsdk:"field_name"`
+	Type      types.String `tfsdk:"type"`
+	Encoding  types.String `tfsdk:"encoding"`
+}
+
+// bigtableTypes and bigtableEncodings are the value types and encodings
+// Bigtable cells can be converted from
+var (
+	bigtableTypes     = []string{"STRING", "NUMBER", "INTEGER", "VAR_INTEGER", "BIG_NUMERIC", "BOOLEAN", "JSON"}
+	bigtableEncodings = []string{"TEXT", "BINARY"}
+)
+
+// sourceProjectIDAttribute returns the optional project_id attribute of an import source
+func sourceProjectIDAttribute(source string) schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional:    true,
+		Description: "Project of the " + source + ". Defaults to the provider's project.",
+	}
+}
+
+// gcsStagingDirAttribute returns the optional gcs_staging_dir attribute of an import source
+func gcsStagingDirAttribute(source string) schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional:    true,
+		Description: "Cloud Storage directory the " + source + " is exported to before the import. The source's service account needs write access to it.",
+		Validators: []validator.String{
+			stringvalidator.RegexMatches(gcsPrefixPattern, "must be a Cloud Storage location of the form gs://bucket or gs://bucket/path/"),
+		},
+	}
+}
+
+// requiredStringAttribute returns a required string attribute
+func requiredStringAttribute(description string) schema.StringAttribute {
+	return schema.StringAttribute{
+		Required:    true,
+		Description: description,
+	}
+}
+
+// gcsSourceBlock returns the schema of the gcs_source block
+func gcsSourceBlock(description string) schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		Description: description,
+		Attributes: map[string]schema.Attribute{
+			"input_uris": schema.ListAttribute{
+				ElementType: types.StringType,
+				Required:    true,
+				Description: "GCS URIs or patterns to import documents from (e.g., gs://bucket/path/*.jsonl), at most 100",
+				Validators: []validator.List{
+					listvalidator.SizeBetween(1, maxGCSInputURIs),
+					listvalidator.ValueStringsAre(
+						stringvalidator.RegexMatches(gcsURIPattern, gcsURIPatternMessage),
+						stringvalidator.LengthAtMost(2000),
+					),
+				},
+			},
+			"data_schema": schema.StringAttribute{
+				Optional:    true,
+				Description: "Format of the imported files: document, custom, csv or content. Defaults to document.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("document", "custom", "csv", "content"),
+				},
+			},
+		},
+	}
+}
+
+// cloudSQLSourceBlock returns the schema of the cloud_sql_source block
+func cloudSQLSourceBlock(description string) schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		Description: description,
+		Attributes: map[string]schema.Attribute{
+			"project_id":      sourceProjectIDAttribute("Cloud SQL instance"),
+			"instance_id":     requiredStringAttribute("Cloud SQL instance to import from"),
+			"database_id":     requiredStringAttribute("Database of the instance to import from"),
+			"table_id":        requiredStringAttribute("Table of the database to import from"),
+			"gcs_staging_dir": gcsStagingDirAttribute("Cloud SQL table"),
+			"offload": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Use a serverless export, which avoids load on the instance at additional cost",
+			},
+		},
+	}
+}
+
+// spannerSourceBlock returns the schema of the spanner_source block
+func spannerSourceBlock(description string) schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		Description: description,
+		Attributes: map[string]schema.Attribute{
+			"project_id":  sourceProjectIDAttribute("Spanner instance"),
+			"instance_id": requiredStringAttribute("Spanner instance to import from"),
+			"database_id": requiredStringAttribute("Database of the instance to import from"),
+			"table_id":    requiredStringAttribute("Table of the database to import from"),
+			"enable_data_boost": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Export with Spanner Data Boost, which avoids load on the instance at additional cost",
+			},
+		},
+	}
+}
+
+// alloyDBSourceBlock returns the schema of the alloydb_source block
+func alloyDBSourceBlock(description string) schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		Description: description,
+		Attributes: map[string]schema.Attribute{
+			"project_id":      sourceProjectIDAttribute("AlloyDB cluster"),
+			"location_id":     requiredStringAttribute("Location of the AlloyDB cluster"),
+			"cluster_id":      requiredStringAttribute("AlloyDB cluster to import from"),
+			"database_id":     requiredStringAttribute("Database of the cluster to import from"),
+			"table_id":        requiredStringAttribute("Table of the database to import from"),
+			"gcs_staging_dir": gcsStagingDirAttribute("AlloyDB table"),
+		},
+	}
+}
+
+// firestoreSourceBlock returns the schema of the firestore_source block
+func firestoreSourceBlock(description string) schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		Description: description,
+		Attributes: map[string]schema.Attribute{
+			"project_id":      sourceProjectIDAttribute("Firestore database"),
+			"database_id":     requiredStringAttribute("Firestore database to import from, e.g. (default)"),
+			"collection_id":   requiredStringAttribute("Collection to import from"),
+			"gcs_staging_dir": gcsStagingDirAttribute("Firestore collection"),
+		},
+	}
+}
+
+// bigtableSourceBlock returns the schema of the bigtable_source block
+func bigtableSourceBlock(description string) schema.SingleNestedBlock {
+	typeAttribute := func(scope string) schema.StringAttribute {
+		return schema.StringAttribute{
+			Optional:    true,
+			Description: "Type of the values in the " + scope + ": " + strings.Join(bigtableTypes, ", "),
+			Validators: []validator.String{
+				stringvalidator.OneOf(bigtableTypes...),
+			},
+		}
+	}
+	encodingAttribute := func(scope string) schema.StringAttribute {
+		return schema.StringAttribute{
+			Optional:    true,
+			Description: "Encoding of non-string values in the " + scope + ": TEXT or BINARY (HBase Bytes.toBytes)",
+			Validators: []validator.String{
+				stringvalidator.OneOf(bigtableEncodings...),
+			},
+		}
+	}
+
+	return schema.SingleNestedBlock{
+		Description: description,
+		Attributes: map[string]schema.Attribute{
+			"project_id":  sourceProjectIDAttribute("Bigtable instance"),
+			"instance_id": requiredStringAttribute("Bigtable instance to import from"),
+			"table_id":    requiredStringAttribute("Table of the instance to import from"),
+			"key_field_name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Document field the row key is stored in",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"column_family": schema.SetNestedBlock{
+				Description: "Column family to import; families without a block are ignored",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"name": requiredStringAttribute("Name of the column family"),
+						"field_name": schema.StringAttribute{
+							Optional:    true,
+							Description: "Document field the column family is stored in. Defaults to a name derived from the family name.",
+						},
+						"type":     typeAttribute("column family"),
+						"encoding": encodingAttribute("column family"),
+					},
+					Blocks: map[string]schema.Block{
+						"column": schema.ListNestedBlock{
+							Description: "Column of the family with its own field name, type or encoding",
+							NestedObject: schema.NestedBlockObject{
+								Attributes: map[string]schema.Attribute{
+									"qualifier": requiredStringAttribute("Qualifier of the column, base64 encoded if it is not valid UTF-8"),
+									"field_name": schema.StringAttribute{
+										Optional:    true,
+										Description: "Document field the column is stored in. Defaults to a name derived from the qualifier.",
+									},
+									"type":     typeAttribute("column"),
+									"encoding": encodingAttribute("column"),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// bigQuerySourceBlock returns the schema of the bigquery_source block
+func bigQuerySourceBlock(description string, validators ...validator.Object) schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		Description: description,
+		Validators:  validators,
+		Attributes: map[string]schema.Attribute{
+			"project_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "Project of the BigQuery table. Defaults to the provider's project.",
+			},
+			"dataset_id": schema.StringAttribute{
+				Required:    true,
+				Description: "BigQuery dataset to import from",
+			},
+			"table_id": schema.StringAttribute{
+				Required:    true,
+				Description: "BigQuery table to import from",
+			},
+			"partition_date": schema.StringAttribute{
+				Optional:    true,
+				Description: "Partition of a time partitioned table to import, as YYYY-MM-DD",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(partitionDatePattern, "must be a date of the form YYYY-MM-DD"),
+				},
+			},
+			"data_schema": schema.StringAttribute{
+				Optional:    true,
+				Description: "Format of the table rows: document (rows with id, jsonData or structData and content columns) or custom (columns matching the data store schema). Defaults to document.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("document", "custom"),
+				},
+			},
+		},
+	}
+}
+
+// bigQuerySourceFromObject converts the bigquery_source block to its client
+// form, returning nil when the block is not set
+func bigQuerySourceFromObject(ctx context.Context, obj types.Object) (*client.BigQuerySource, diag.Diagnostics) {
+	if obj.IsNull() || obj.IsUnknown() {
+		return nil, nil
+	}
+
+	var source bigQuerySourceModel
+	diags := obj.As(ctx, &source, basetypes.ObjectAsOptions{})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	dataSchema := source.DataSchema.ValueString()
+	if dataSchema == "" {
+		dataSchema = "document"
+	}
+
+	return &client.BigQuerySource{
+		ProjectID:     source.ProjectID.ValueString(),
+		DatasetID:     source.DatasetID.ValueString(),
+		TableID:       source.TableID.ValueString(),
+		PartitionDate: source.PartitionDate.ValueString(),
+		DataSchema:    dataSchema,
+	}, diags
+}
+
+// gcsSourceFromObject converts the gcs_source block to its client form,
+// returning nil when the block is not set
+func gcsSourceFromObject(ctx context.Context, obj types.Object) (*client.GCSSource, diag.Diagnostics) {
+	if obj.IsNull() || obj.IsUnknown() {
+		return nil, nil
+	}
+
+	var source gcsSourceModel
+	diags := obj.As(ctx, &source, basetypes.ObjectAsOptions{})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	var uris []string
+	diags.Append(source.InputURIs.ElementsAs(ctx, &uris, false)...)
+
+	dataSchema := source.DataSchema.ValueString()
+	if dataSchema == "" {
+		dataSchema = "document"
+	}
+
+	return &client.GCSSource{
+		InputURIs:  uris,
+		DataSchema: dataSchema,
+	}, diags
+}
+
+// cloudSQLSourceFromObject converts the cloud_sql_source block to its client
+// form, returning nil when the block is not set
+func cloudSQLSourceFromObject(ctx context.Context, obj types.Object) (*client.CloudSQLSource, diag.Diagnostics) {
+	if obj.IsNull() || obj.IsUnknown() {
+		return nil, nil
+	}
+
+	var source cloudSQLSourceModel
+	diags := obj.As(ctx, &source, basetypes.ObjectAsOptions{})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	return &client.CloudSQLSource{
+		ProjectID:     source.ProjectID.ValueString(),
+		InstanceID:    source.InstanceID.ValueString(),
+		DatabaseID:    source.DatabaseID.ValueString(),
+		TableID:       source.TableID.ValueString(),
+		GCSStagingDir: source.GCSStagingDir.ValueString(),
+		Offload:       source.Offload.ValueBool(),
+	}, diags
+}
+
+// spannerSourceFromObject converts the spanner_source block to its client
+// form, returning nil when the block is not set
+func spannerSourceFromObject(ctx context.Context, obj types.Object) (*client.SpannerSource, diag.Diagnostics) {
+	if obj.IsNull() || obj.IsUnknown() {
+		return nil, nil
+	}
+
+	var source spannerSourceModel
+	diags := obj.As(ctx, &source, basetypes.ObjectAsOptions{})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	return &client.SpannerSource{
+		ProjectID:       source.ProjectID.ValueString(),
+		InstanceID:      source.InstanceID.ValueString(),
+		DatabaseID:      source.DatabaseID.ValueString(),
+		TableID:         source.TableID.ValueString(),
+		EnableDataBoost: source.EnableDataBoost.ValueBool(),
+	}, diags
+}
+
+// alloyDBSourceFromObject converts the alloydb_source block to its client
+// form, returning nil when the block is not set
+func alloyDBSourceFromObject(ctx context.Context, obj types.Object) (*client.AlloyDBSource, diag.Diagnostics) {
+	if obj.IsNull() || obj.IsUnknown() {
+		return nil, nil
+	}
+
+	var source alloyDBSourceModel
+	diags := obj.As(ctx, &source, basetypes.ObjectAsOptions{})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	return &client.AlloyDBSource{
+		ProjectID:     source.ProjectID.ValueString(),
+		LocationID:    source.LocationID.ValueString(),
+		ClusterID:     source.ClusterID.ValueString(),
+		DatabaseID:    source.DatabaseID.ValueString(),
+		TableID:       source.TableID.ValueString(),
+		GCSStagingDir: source.GCSStagingDir.ValueString(),
+	}, diags
+}
+
+// firestoreSourceFromObject converts the firestore_source block to its client
+// form, returning nil when the block is not set
+func firestoreSourceFromObject(ctx context.Context, obj types.Object) (*client.FirestoreSource, diag.Diagnostics) {
+	if obj.IsNull() || obj.IsUnknown() {
+		return nil, nil
+	}
+
+	var source firestoreSourceModel
+	diags := obj.As(ctx, &source, basetypes.ObjectAsOptions{})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	return &client.FirestoreSource{
+		ProjectID:     source.ProjectID.ValueString(),
+		DatabaseID:    source.DatabaseID.ValueString(),
+		CollectionID:  source.CollectionID.ValueString(),
+		GCSStagingDir: source.GCSStagingDir.ValueString(),
+	}, diags
+}
+
+// bigtableSourceFromObject converts the bigtable_source block to its client
+// form, returning nil when the block is not set
+func bigtableSourceFromObject(ctx context.Context, obj types.Object) (*client.BigtableSource, diag.Diagnostics) {
+	if obj.IsNull() || obj.IsUnknown() {
+		return nil, nil
+	}
+
+	var source bigtableSourceModel
+	diags := obj.As(ctx, &source, basetypes.ObjectAsOptions{})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	result := &client.BigtableSource{
+		ProjectID:    source.ProjectID.ValueString(),
+		InstanceID:   source.InstanceID.ValueString(),
+		TableID:      source.TableID.ValueString(),
+		KeyFieldName: source.KeyFieldName.ValueString(),
+	}
+
+	var families []bigtableColumnFamilyModel
+	diags.Append(source.ColumnFamily.ElementsAs(ctx, &families, false)...)
+	if len(families) > 0 {
+		result.Families = make(map[string]client.BigtableColumnFamily)
+	}
+	for _, family := range families {
+		var columns []bigtableColumnModel
+		diags.Append(family.Column.ElementsAs(ctx, &columns, false)...)
+
+		clientFamily := client.BigtableColumnFamily{
+			FieldName: family.FieldName.ValueString(),
+			Type:      family.Type.ValueString(),
+			Encoding:  family.Encoding.ValueString(),
+		}
+		for _, column := range columns {
+			clientFamily.Columns = append(clientFamily.Columns, client.BigtableColumn{
+				Qualifier: column.Qualifier.ValueString(),
+				FieldName: column.FieldName.ValueString(),
+				Type:      column.Type.ValueString(),
+				Encoding:  column.Encoding.ValueString(),
+			})
+		}
+		result.Families[family.Name.ValueString()] = clientFamily
+	}
+
+	return result, diags
+}
@@ -0,0 +1,178 @@
+package provider
+
+import (
+	"testing"
+
+	client "github.com/vb140772/terraform-provider-gemctl/internal/client"
+)
+
+var testImportConfig = &client.Config{
+	ProjectID:  "my-project",
+	Location:   "global",
+	Collection: "default_collection",
+}
+
+func TestShortIDFromImportID(t *testing.T) {
+	tests := []struct {
+		name         string
+		importID     string
+		resourceType string
+		want         string
+		wantErr      bool
+	}{
+		{name: "short ID", importID: "my-engine", resourceType: "engines", want: "my-engine"},
+		{name: "empty", importID: "", resourceType: "engines", wantErr: true},
+		{
+			name:         "full name",
+			importID:     "projects/my-project/locations/global/collections/default_collection/engines/my-engine",
+			resourceType: "engines",
+			want:         "my-engine",
+		},
+		{
+			name:         "project number",
+			importID:     "projects/123456789/locations/global/collections/default_collection/dataStores/my-store",
+			resourceType: "dataStores",
+			want:         "my-store",
+		},
+		{
+			name:         "other project",
+			importID:     "projects/other-project/locations/global/collections/default_collection/engines/my-engine",
+			resourceType: "engines",
+			wantErr:      true,
+		},
+		{
+			name:         "other location",
+			importID:     "projects/my-project/locations/us/collections/default_collection/engines/my-engine",
+			resourceType: "engines",
+			wantErr:      true,
+		},
+		{
+			name:         "other collection",
+			importID:     "projects/my-project/locations/global/collections/other_collection/engines/my-engine",
+			resourceType: "engines",
+			wantErr:      true,
+		},
+		{
+			name:         "other resource type",
+			importID:     "projects/my-project/locations/global/collections/default_collection/dataStores/my-store",
+			resourceType: "engines",
+			wantErr:      true,
+		},
+		{
+			name:         "trailing segments",
+			importID:     "projects/my-project/locations/global/collections/default_collection/engines/my-engine/servingConfigs/default",
+			resourceType: "engines",
+			wantErr:      true,
+		},
+		{name: "partial name", importID: "engines/my-engine", resourceType: "engines", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := shortIDFromImportID(testImportConfig, tt.importID, tt.resourceType)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("shortIDFromImportID(%q) error = %v, wantErr %v", tt.importID, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("shortIDFromImportID(%q) = %q, want %q", tt.importID, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDocumentIDsFromImportID(t *testing.T) {
+	const dataStoreName = "projects/my-project/locations/global/collections/default_collection/dataStores/my-store"
+
+	tests := []struct {
+		name          string
+		importID      string
+		wantDataStore string
+		wantBranch    string
+		wantDocument  string
+		wantErr       bool
+	}{
+		{
+			name:          "data store and document",
+			importID:      "my-store/doc-1",
+			wantDataStore: "my-store",
+			wantBranch:    client.DefaultBranch,
+			wantDocument:  "doc-1",
+		},
+		{
+			name:          "data store, branch and document",
+			importID:      "my-store/1/doc-1",
+			wantDataStore: "my-store",
+			wantBranch:    "1",
+			wantDocument:  "doc-1",
+		},
+		{
+			name:          "full name",
+			importID:      dataStoreName + "/branches/default_branch/documents/doc-1",
+			wantDataStore: "my-store",
+			wantBranch:    "default_branch",
+			wantDocument:  "doc-1",
+		},
+		{name: "data store only", importID: "my-store", wantErr: true},
+		{name: "too many parts", importID: "my-store/1/doc-1/extra", wantErr: true},
+		{name: "empty document", importID: "my-store/", wantErr: true},
+		{name: "full name without document", importID: dataStoreName + "/branches/default_branch", wantErr: true},
+		{name: "full name with wrong collection", importID: dataStoreName + "/branches/default_branch/schemas/doc-1", wantErr: true},
+		{
+			name:     "full name in another location",
+			importID: "projects/my-project/locations/us/collections/default_collection/dataStores/my-store/branches/default_branch/documents/doc-1",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dataStoreID, branch, documentID, err := documentIDsFromImportID(testImportConfig, tt.importID)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("documentIDsFromImportID(%q) error = %v, wantErr %v", tt.importID, err, tt.wantErr)
+			}
+			if dataStoreID != tt.wantDataStore || branch != tt.wantBranch || documentID != tt.wantDocument {
+				t.Errorf("documentIDsFromImportID(%q) = %q, %q, %q, want %q, %q, %q",
+					tt.importID, dataStoreID, branch, documentID, tt.wantDataStore, tt.wantBranch, tt.wantDocument)
+			}
+		})
+	}
+}
+
+func TestSchemaIDsFromImportID(t *testing.T) {
+	const dataStoreName = "projects/my-project/locations/global/collections/default_collection/dataStores/my-store"
+
+	tests := []struct {
+		name          string
+		importID      string
+		wantDataStore string
+		wantSchema    string
+		wantErr       bool
+	}{
+		{name: "data store only", importID: "my-store", wantDataStore: "my-store", wantSchema: client.DefaultSchemaID},
+		{name: "data store and schema", importID: "my-store/custom", wantDataStore: "my-store", wantSchema: "custom"},
+		{name: "full data store name", importID: dataStoreName, wantDataStore: "my-store", wantSchema: client.DefaultSchemaID},
+		{name: "full schema name", importID: dataStoreName + "/schemas/custom", wantDataStore: "my-store", wantSchema: "custom"},
+		{name: "empty schema", importID: "my-store/", wantErr: true},
+		{name: "full name with empty schema", importID: dataStoreName + "/schemas/", wantErr: true},
+		{name: "schema with slash", importID: dataStoreName + "/schemas/a/b", wantErr: true},
+		{name: "too many parts", importID: "my-store/custom/extra", wantErr: true},
+		{
+			name:     "full name in another project",
+			importID: "projects/other-project/locations/global/collections/default_collection/dataStores/my-store/schemas/custom",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dataStoreID, schemaID, err := schemaIDsFromImportID(testImportConfig, tt.importID)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("schemaIDsFromImportID(%q) error = %v, wantErr %v", tt.importID, err, tt.wantErr)
+			}
+			if dataStoreID != tt.wantDataStore || schemaID != tt.wantSchema {
+				t.Errorf("schemaIDsFromImportID(%q) = %q, %q, want %q, %q",
+					tt.importID, dataStoreID, schemaID, tt.wantDataStore, tt.wantSchema)
+			}
+		})
+	}
+}
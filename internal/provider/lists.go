@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// refreshStringList returns the list to store for a string list attribute read
+// back from the API. The API does not preserve element order, so the current
+// value is kept when it holds the same elements; otherwise the remote elements
+// are returned so the drift shows up in the plan. A null current value stays
+// null while the remote list is empty.
+func refreshStringList(ctx context.Context, current types.List, remote []string) (types.List, diag.Diagnostics) {
+	if current.IsNull() && len(remote) == 0 {
+		return current, nil
+	}
+
+	if !current.IsUnknown() && !current.IsNull() {
+		var elements []string
+		diags := current.ElementsAs(ctx, &elements, false)
+		if diags.HasError() {
+			return current, diags
+		}
+		if sameStrings(elements, remote) {
+			return current, nil
+		}
+	}
+
+	return types.ListValueFrom(ctx, types.StringType, remote)
+}
+
+// sameStrings reports whether a and b hold the same elements in any order
+func sameStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	sortedA := append([]string(nil), a...)
+	sortedB := append([]string(nil), b...)
+	sort.Strings(sortedA)
+	sort.Strings(sortedB)
+
+	for i := range sortedA {
+		if sortedA[i] != sortedB[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,178 @@
+package provider
+
+import (
+	"fmt"
+	"io/fs"
+	"mime"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// documentIDInvalidChars matches the characters of a file path that are not
+// allowed in a document ID
+var documentIDInvalidChars = regexp.MustCompile(`[^a-zA-Z0-9_-]`)
+
+// documentSetMimeTypes maps the file extensions the API can parse to their
+// MIME types; other extensions fall back to the system MIME database
+var documentSetMimeTypes = map[string]string{
+	".pdf":  "application/pdf",
+	".html": "text/html",
+	".htm":  "text/html",
+	".txt":  "text/plain",
+	".md":   "text/plain",
+	".docx": "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+	".pptx": "application/vnd.openxmlformats-officedocument.presentationml.presentation",
+	".xlsx": "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	".xlsm": "application/vnd.ms-excel.sheet.macroenabled.12",
+}
+
+// localDocument is a file of the directory that is synced as a document
+type localDocument struct {
+	Path     string
+	MimeType string
+	SHA256   string
+}
+
+// scanLocalDocuments walks root for files matching one of the patterns and
+// returns them by document ID
+func scanLocalDocuments(root string, patterns []string, mimeTypes map[string]string) (map[string]localDocument, error) {
+	documents := make(map[string]localDocument)
+	paths := make(map[string]string)
+
+	err := filepath.WalkDir(root, func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, name)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if !matchesAnyGlob(patterns, rel) {
+			return nil
+		}
+
+		id, err := documentIDFromPath(rel)
+		if err != nil {
+			return err
+		}
+		if other, exists := paths[id]; exists {
+			return fmt.Errorf("%s and %s both map to document ID %q; rename one of them or narrow the patterns", other, rel, id)
+		}
+		paths[id] = rel
+
+		mimeType, err := documentMimeType(rel, mimeTypes)
+		if err != nil {
+			return err
+		}
+
+		hash, _, err := readFileSHA256(name)
+		if err != nil {
+			return err
+		}
+
+		documents[id] = localDocument{
+			Path:     name,
+			MimeType: mimeType,
+			SHA256:   hash,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return documents, nil
+}
+
+// diffDocuments compares the local documents with the current ones and returns
+// the sorted IDs of the documents to add, change and remove
+func diffDocuments(local map[string]localDocument, current map[string]string) (added, changed, removed []string) {
+	for id, doc := range local {
+		hash, exists := current[id]
+		switch {
+		case !exists:
+			added = append(added, id)
+		case hash != doc.SHA256:
+			changed = append(changed, id)
+		}
+	}
+	for id := range current {
+		if _, exists := local[id]; !exists {
+			removed = append(removed, id)
+		}
+	}
+
+	sort.Strings(added)
+	sort.Strings(changed)
+	sort.Strings(removed)
+	return added, changed, removed
+}
+
+// documentIDFromPath derives a document ID from a relative file path by
+// replacing the characters not allowed in document IDs with hyphens
+func documentIDFromPath(rel string) (string, error) {
+	id := documentIDInvalidChars.ReplaceAllString(rel, "-")
+	if !documentIDPattern.MatchString(id) || len(id) > 128 {
+		return "", fmt.Errorf("%s does not map to a valid document ID (%q); document IDs must start with a letter or digit and be at most 128 characters long", rel, id)
+	}
+	return id, nil
+}
+
+// documentMimeType returns the MIME type of a file from its extension
+func documentMimeType(rel string, overrides map[string]string) (string, error) {
+	ext := strings.ToLower(path.Ext(rel))
+	if mimeType, ok := overrides[ext]; ok {
+		return mimeType, nil
+	}
+	if mimeType, ok := documentSetMimeTypes[ext]; ok {
+		return mimeType, nil
+	}
+	if mimeType := mime.TypeByExtension(ext); mimeType != "" {
+		return strings.Split(mimeType, ";")[0], nil
+	}
+	return "", fmt.Errorf("cannot determine the MIME type of %s; add its extension to mime_types or exclude it with patterns", rel)
+}
+
+// matchesAnyGlob reports whether a slash separated relative path matches one
+// of the patterns
+func matchesAnyGlob(patterns []string, rel string) bool {
+	for _, pattern := range patterns {
+		if matchGlob(strings.Split(pattern, "/"), strings.Split(rel, "/")) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchGlob matches path segments against pattern segments, where a ** segment
+// matches any number of path segments
+func matchGlob(pattern, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+
+	if pattern[0] == "**" {
+		for i := 0; i <= len(segments); i++ {
+			if matchGlob(pattern[1:], segments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(segments) == 0 {
+		return false
+	}
+	if ok, err := path.Match(pattern[0], segments[0]); err != nil || !ok {
+		return false
+	}
+	return matchGlob(pattern[1:], segments[1:])
+}
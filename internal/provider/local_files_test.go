@@ -0,0 +1,186 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestMatchesAnyGlob(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		rel      string
+		want     bool
+	}{
+		{name: "top level", patterns: []string{"*.pdf"}, rel: "a.pdf", want: true},
+		{name: "star does not cross directories", patterns: []string{"*.pdf"}, rel: "docs/a.pdf", want: false},
+		{name: "double star at any depth", patterns: []string{"**/*.pdf"}, rel: "docs/2024/a.pdf", want: true},
+		{name: "double star matches zero directories", patterns: []string{"**/*.pdf"}, rel: "a.pdf", want: true},
+		{name: "double star in the middle", patterns: []string{"docs/**/*.md"}, rel: "docs/a/b/c.md", want: true},
+		{name: "double star in the middle without directories", patterns: []string{"docs/**/*.md"}, rel: "docs/c.md", want: true},
+		{name: "other prefix", patterns: []string{"docs/**/*.md"}, rel: "notes/c.md", want: false},
+		{name: "trailing double star", patterns: []string{"docs/**"}, rel: "docs/a/b.txt", want: true},
+		{name: "second pattern", patterns: []string{"*.pdf", "**/*.html"}, rel: "site/index.html", want: true},
+		{name: "extension mismatch", patterns: []string{"**/*.pdf"}, rel: "docs/a.txt", want: false},
+		{name: "character class", patterns: []string{"report-[0-9].pdf"}, rel: "report-7.pdf", want: true},
+		{name: "malformed pattern", patterns: []string{"[.pdf"}, rel: "[.pdf", want: false},
+		{name: "no patterns", patterns: nil, rel: "a.pdf", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchesAnyGlob(tt.patterns, tt.rel); got != tt.want {
+				t.Errorf("matchesAnyGlob(%q, %q) = %v, want %v", tt.patterns, tt.rel, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDocumentIDFromPath(t *testing.T) {
+	tests := []struct {
+		name    string
+		rel     string
+		want    string
+		wantErr bool
+	}{
+		{name: "plain file", rel: "guide.pdf", want: "guide-pdf"},
+		{name: "nested file", rel: "docs/2024/guide.pdf", want: "docs-2024-guide-pdf"},
+		{name: "spaces and punctuation", rel: "Q1 report (final).docx", want: "Q1-report--final--docx"},
+		{name: "underscores kept", rel: "release_notes.md", want: "release_notes-md"},
+		{name: "leading dot", rel: ".hidden.txt", wantErr: true},
+		{name: "leading underscore", rel: "_draft.md", wantErr: true},
+		{name: "too long", rel: strings.Repeat("a", 125) + ".pdf", wantErr: true},
+		{name: "maximum length", rel: strings.Repeat("a", 124) + ".pdf", want: strings.Repeat("a", 124) + "-pdf"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := documentIDFromPath(tt.rel)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("documentIDFromPath(%q) error = %v, wantErr %v", tt.rel, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("documentIDFromPath(%q) = %q, want %q", tt.rel, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScanLocalDocumentsIDCollision(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   []string
+		wantIDs []string
+		wantErr string
+	}{
+		{
+			name:    "distinct IDs",
+			files:   []string{"a.pdf", "docs/a.pdf"},
+			wantIDs: []string{"a-pdf", "docs-a-pdf"},
+		},
+		{
+			name:    "directory and file name collide",
+			files:   []string{"docs/a.pdf", "docs-a.pdf"},
+			wantErr: `both map to document ID "docs-a-pdf"`,
+		},
+		{
+			name:    "punctuation collides",
+			files:   []string{"a b.pdf", "a_b.pdf", "a-b.pdf"},
+			wantErr: `both map to document ID "a-b-pdf"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			for _, file := range tt.files {
+				name := filepath.Join(root, filepath.FromSlash(file))
+				if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(name, []byte(file), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			documents, err := scanLocalDocuments(root, []string{"**/*.pdf"}, nil)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("scanLocalDocuments() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("scanLocalDocuments() error = %v", err)
+			}
+
+			var ids []string
+			for id := range documents {
+				ids = append(ids, id)
+			}
+			sort.Strings(ids)
+			if !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Errorf("scanLocalDocuments() IDs = %q, want %q", ids, tt.wantIDs)
+			}
+		})
+	}
+}
+
+func TestDiffDocuments(t *testing.T) {
+	local := map[string]localDocument{
+		"same":    {SHA256: "1"},
+		"changed": {SHA256: "2"},
+		"new-b":   {SHA256: "3"},
+		"new-a":   {SHA256: "4"},
+	}
+	current := map[string]string{
+		"same":    "1",
+		"changed": "old",
+		"gone":    "5",
+	}
+
+	added, changed, removed := diffDocuments(local, current)
+
+	if want := []string{"new-a", "new-b"}; !reflect.DeepEqual(added, want) {
+		t.Errorf("added = %q, want %q", added, want)
+	}
+	if want := []string{"changed"}; !reflect.DeepEqual(changed, want) {
+		t.Errorf("changed = %q, want %q", changed, want)
+	}
+	if want := []string{"gone"}; !reflect.DeepEqual(removed, want) {
+		t.Errorf("removed = %q, want %q", removed, want)
+	}
+}
+
+func TestDocumentMimeType(t *testing.T) {
+	tests := []struct {
+		name      string
+		rel       string
+		overrides map[string]string
+		want      string
+		wantErr   bool
+	}{
+		{name: "pdf", rel: "a.pdf", want: "application/pdf"},
+		{name: "upper case extension", rel: "a.PDF", want: "application/pdf"},
+		{name: "markdown as plain text", rel: "docs/a.md", want: "text/plain"},
+		{name: "override", rel: "a.md", overrides: map[string]string{".md": "text/markdown"}, want: "text/markdown"},
+		{name: "unknown extension", rel: "a.unknownext", wantErr: true},
+		{name: "no extension", rel: "README", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := documentMimeType(tt.rel, tt.overrides)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("documentMimeType(%q) error = %v, wantErr %v", tt.rel, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("documentMimeType(%q) = %q, want %q", tt.rel, got, tt.want)
+			}
+		})
+	}
+}
@@ -2,11 +2,16 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	client "github.com/vb140772/terraform-provider-gemctl/internal/client"
@@ -20,10 +25,12 @@ type gemctlProvider struct {
 }
 
 type gemctlProviderModel struct {
-	ProjectID          types.String `tfsdk:"project_id"`
-	Location           types.String `tfsdk:"location"`
-	Collection         types.String `tfsdk:"collection"`
-	UseServiceAccount  types.Bool   `tfsdk:"use_service_account"`
+	ProjectID         types.String `tfsdk:"project_id"`
+	Location          types.String `tfsdk:"location"`
+	Collection        types.String `tfsdk:"collection"`
+	UseServiceAccount types.Bool   `tfsdk:"use_service_account"`
+	MaxRetries        types.Int64  `tfsdk:"max_retries"`
+	RetryMaxBackoff   types.String `tfsdk:"retry_max_backoff"`
 }
 
 func New() provider.Provider {
@@ -55,6 +62,17 @@ func (p *gemctlProvider) Schema(_ context.Context, _ provider.SchemaRequest, res
 				Optional:            true,
 				MarkdownDescription: "Use service account credentials instead of user credentials. When false, uses `gcloud auth print-access-token`.",
 			},
+			"max_retries": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Maximum number of times a request is retried after a transient failure such as HTTP 429 `RESOURCE_EXHAUSTED` or 503. Requests that may have been processed are only retried when repeating them is safe. Defaults to `5`; set to `0` to disable retries.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"retry_max_backoff": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Upper bound on the jittered exponential delay between retries, as a duration such as `30s` or `2m`. A `Retry-After` header sent by the API takes precedence. Defaults to `30s`.",
+			},
 		},
 	}
 }
@@ -76,14 +94,34 @@ func (p *gemctlProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		collection = "default_collection"
 	}
 
+	maxRetries := client.DefaultMaxRetries
+	if !config.MaxRetries.IsNull() {
+		maxRetries = int(config.MaxRetries.ValueInt64())
+	}
+	retryMaxBackoff := client.DefaultRetryMaxBackoff
+	if !config.RetryMaxBackoff.IsNull() {
+		var err error
+		retryMaxBackoff, err = time.ParseDuration(config.RetryMaxBackoff.ValueString())
+		if err != nil || retryMaxBackoff <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("retry_max_backoff"),
+				"Invalid retry_max_backoff",
+				fmt.Sprintf("Expected a positive duration such as \"30s\" or \"2m\", got %q.", config.RetryMaxBackoff.ValueString()),
+			)
+			return
+		}
+	}
+
 	clientConfig := &client.Config{
 		ProjectID:         config.ProjectID.ValueString(),
 		Location:          location,
 		Collection:        collection,
 		UseServiceAccount: config.UseServiceAccount.ValueBool(),
+		MaxRetries:        maxRetries,
+		RetryMaxBackoff:   retryMaxBackoff,
 	}
 
-	geminiClient, err := client.NewGeminiClient(clientConfig)
+	geminiClient, err := client.NewGeminiClient(ctx, clientConfig)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to create Gemini client",
@@ -94,7 +132,7 @@ func (p *gemctlProvider) Configure(ctx context.Context, req provider.ConfigureRe
 
 	resp.DataSourceData = geminiClient
 	resp.ResourceData = geminiClient
-	
+
 	// Store client in provider
 	p.client = geminiClient
 }
@@ -103,6 +141,10 @@ func (p *gemctlProvider) Resources(_ context.Context) []func() resource.Resource
 	return []func() resource.Resource{
 		func() resource.Resource { return NewEngineResource(p.client) },
 		func() resource.Resource { return NewDataStoreResource(p.client) },
+		func() resource.Resource { return NewDataStoreSchemaResource(p.client) },
+		func() resource.Resource { return NewDocumentResource(p.client) },
+		func() resource.Resource { return NewDocumentSetResource(p.client) },
+		func() resource.Resource { return NewDocumentsImportResource(p.client) },
 	}
 }
 
@@ -110,6 +152,6 @@ func (p *gemctlProvider) DataSources(_ context.Context) []func() datasource.Data
 	return []func() datasource.DataSource{
 		func() datasource.DataSource { return NewEngineDataSource(p.client) },
 		func() datasource.DataSource { return NewDataStoreDataSource(p.client) },
+		func() datasource.DataSource { return NewDataStoreSchemaDataSource(p.client) },
 	}
 }
-